@@ -3,8 +3,17 @@ package api
 import (
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/v1"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/config"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/avatar"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/brute_force"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/cooldown"
 	grpcPkg "github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/grpc_client"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/markdown"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/media"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/scheduler"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/storage"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/view_counter"
 	"github.com/gin-gonic/gin"
+	"log"
 
 	_ "github.com/MuhammadyusufAdhamov/medium_api_gateway/api/docs"
 	swaggerFiles "github.com/swaggo/files"     // swagger embed files
@@ -12,8 +21,19 @@ import (
 )
 
 type RouterOptions struct {
-	Cfg        *config.Config
-	GrpcClient grpcPkg.GrpcClientI
+	Cfg            *config.Config
+	GrpcClient     grpcPkg.GrpcClientI
+	ViewCounter    *view_counter.Counter
+	Storage        storage.Storage
+	MediaCache     *media.Cache
+	Resizer        *media.Resizer
+	Publisher      *scheduler.Publisher
+	Markdown       *markdown.Renderer
+	BruteForce     *brute_force.Limiter
+	CodeAttempts   *brute_force.Limiter
+	ResendCooldown *cooldown.Cooldown
+	Avatars        *avatar.Generator
+	AvatarCache    *media.Cache
 }
 
 // @title           Swagger for blog api
@@ -28,28 +48,109 @@ type RouterOptions struct {
 func New(opt *RouterOptions) *gin.Engine {
 	router := gin.Default()
 
+	err := v1.RegisterValidators(opt.Cfg.PhoneDefaultRegion)
+	if err != nil {
+		log.Fatalf("failed to register validators: %v", err)
+	}
+
 	handlerV1 := v1.New(&v1.HandlerV1Options{
-		Cfg:        opt.Cfg,
-		GrpcClient: opt.GrpcClient,
+		Cfg:            opt.Cfg,
+		GrpcClient:     opt.GrpcClient,
+		ViewCounter:    opt.ViewCounter,
+		Storage:        opt.Storage,
+		MediaCache:     opt.MediaCache,
+		Resizer:        opt.Resizer,
+		Publisher:      opt.Publisher,
+		Markdown:       opt.Markdown,
+		BruteForce:     opt.BruteForce,
+		CodeAttempts:   opt.CodeAttempts,
+		ResendCooldown: opt.ResendCooldown,
+		Avatars:        opt.Avatars,
+		AvatarCache:    opt.AvatarCache,
 	})
 
 	apiV1 := router.Group("/v1")
 
 	apiV1.POST("/auth/register", handlerV1.Register)
 	apiV1.POST("/auth/verify", handlerV1.Verify)
+	apiV1.POST("/auth/verify/resend", handlerV1.ResendVerification)
 	apiV1.POST("/auth/login", handlerV1.Login)
 	apiV1.POST("/auth/forgot_password", handlerV1.VerifyForgotPassword)
 
-	apiV1.POST("/users", handlerV1.CreateUser)
-	apiV1.GET("/users/:id", handlerV1.GetUser)
-	apiV1.PUT("/users/:id", handlerV1.UpdateUser)
-	apiV1.GET("/users", handlerV1.GetAllUsers)
-	apiV1.DELETE("/users/:id", handlerV1.DeleteUser)
-	apiV1.GET("/users/email/:email", handlerV1.GetUserByEmail)
+	apiV1.GET("/@:username", handlerV1.OptionalAuthMiddleware(), handlerV1.GetUserByUsername)
+	apiV1.GET("/users/username-available", handlerV1.OptionalAuthMiddleware(), handlerV1.CheckUsernameAvailable)
+	apiV1.GET("/users/me", handlerV1.AuthMiddleware(), handlerV1.GetMe)
+	apiV1.PATCH("/users/me", handlerV1.AuthMiddleware(), handlerV1.UpdateMe)
+	apiV1.DELETE("/users/me", handlerV1.AuthMiddleware(), handlerV1.DeleteMe)
+	apiV1.POST("/users/me/password", handlerV1.AuthMiddleware(), handlerV1.ChangePassword)
+	apiV1.POST("/users/me/restore", handlerV1.AuthMiddleware(), handlerV1.RestoreMe)
+	apiV1.POST("/users/me/phone/code", handlerV1.AuthMiddleware(), handlerV1.SendPhoneCode)
+	apiV1.POST("/users/me/phone/verify", handlerV1.AuthMiddleware(), handlerV1.VerifyPhone)
+	apiV1.POST("/users/import", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.ImportUsers)
+	apiV1.GET("/users/export", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.ExportUsers)
+	apiV1.GET("/users/pending-deletion", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.GetPendingDeletions)
+
+	apiV1.POST("/users", handlerV1.OptionalAuthMiddleware(), handlerV1.CreateUser)
+	apiV1.GET("/users/:id", handlerV1.OptionalAuthMiddleware(), handlerV1.GetUser)
+	apiV1.PATCH("/users/:id", handlerV1.AuthMiddleware(), handlerV1.UpdateUser)
+	apiV1.GET("/users", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAllUsers)
+	apiV1.DELETE("/users/:id", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.DeleteUser)
+	apiV1.POST("/users/:id/restore", handlerV1.AuthMiddleware(), handlerV1.RestoreUser)
+	apiV1.GET("/users/email/:email", handlerV1.OptionalAuthMiddleware(), handlerV1.GetUserByEmail)
+	apiV1.POST("/users/:id/follow", handlerV1.AuthMiddleware(), handlerV1.FollowUser)
+	apiV1.DELETE("/users/:id/follow", handlerV1.AuthMiddleware(), handlerV1.UnfollowUser)
+	apiV1.GET("/users/:id/avatar.svg", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAvatarSVG)
+	apiV1.GET("/users/:id/avatar.png", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAvatarPNG)
+	apiV1.GET("/users/:id/followers", handlerV1.OptionalAuthMiddleware(), handlerV1.GetFollowers)
+	apiV1.GET("/users/:id/following", handlerV1.OptionalAuthMiddleware(), handlerV1.GetFollowing)
+
+	apiV1.GET("/feed", handlerV1.AuthMiddleware(), handlerV1.GetFeed)
+
+	apiV1.POST("/posts", handlerV1.AuthMiddleware(), handlerV1.CreatePost)
+	apiV1.GET("/posts", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAllPosts)
+	apiV1.GET("/posts/:id", handlerV1.OptionalAuthMiddleware(), handlerV1.GetPost)
+	apiV1.POST("/posts/:id/like", handlerV1.AuthMiddleware(), handlerV1.LikePost)
+	apiV1.POST("/posts/:id/dislike", handlerV1.AuthMiddleware(), handlerV1.DislikePost)
+	apiV1.DELETE("/posts/:id/reaction", handlerV1.AuthMiddleware(), handlerV1.DeletePostReaction)
+	apiV1.PUT("/posts/:id", handlerV1.AuthMiddleware(), handlerV1.UpdatePost)
+	apiV1.POST("/posts/:id/publish", handlerV1.AuthMiddleware(), handlerV1.PublishPost)
+	apiV1.POST("/posts/:id/unpublish", handlerV1.AuthMiddleware(), handlerV1.UnpublishPost)
+	apiV1.POST("/posts/:id/archive", handlerV1.AuthMiddleware(), handlerV1.ArchivePost)
+	apiV1.POST("/posts/:id/schedule", handlerV1.AuthMiddleware(), handlerV1.SchedulePost)
+	apiV1.POST("/posts/:id/bookmark", handlerV1.AuthMiddleware(), handlerV1.BookmarkPost)
+	apiV1.DELETE("/posts/:id/bookmark", handlerV1.AuthMiddleware(), handlerV1.UnbookmarkPost)
+
+	apiV1.POST("/posts/:id/comments", handlerV1.AuthMiddleware(), handlerV1.CreateComment)
+	apiV1.GET("/posts/:id/comments", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAllComments)
+	apiV1.GET("/comments/:id/replies", handlerV1.OptionalAuthMiddleware(), handlerV1.GetCommentReplies)
+	apiV1.PUT("/comments/:id", handlerV1.AuthMiddleware(), handlerV1.UpdateComment)
+	apiV1.DELETE("/comments/:id", handlerV1.AuthMiddleware(), handlerV1.DeleteComment)
+
+	apiV1.POST("/categories", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.CreateCategory)
+	apiV1.GET("/categories/:id", handlerV1.GetCategory)
+	apiV1.GET("/categories", handlerV1.GetAllCategories)
+	apiV1.PUT("/categories/:id", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.UpdateCategory)
+	apiV1.DELETE("/categories/:id", handlerV1.AuthMiddleware(), handlerV1.SuperadminMiddleware(), handlerV1.DeleteCategory)
+	apiV1.POST("/categories/:id/follow", handlerV1.AuthMiddleware(), handlerV1.FollowCategory)
+	apiV1.DELETE("/categories/:id/follow", handlerV1.AuthMiddleware(), handlerV1.UnfollowCategory)
+
+	apiV1.POST("/reading-lists", handlerV1.AuthMiddleware(), handlerV1.CreateReadingList)
+	apiV1.GET("/reading-lists", handlerV1.OptionalAuthMiddleware(), handlerV1.GetAllReadingLists)
+	apiV1.GET("/reading-lists/:id", handlerV1.OptionalAuthMiddleware(), handlerV1.GetReadingList)
+	apiV1.PUT("/reading-lists/:id", handlerV1.AuthMiddleware(), handlerV1.UpdateReadingList)
+	apiV1.DELETE("/reading-lists/:id", handlerV1.AuthMiddleware(), handlerV1.DeleteReadingList)
+	apiV1.GET("/reading-lists/:id/posts", handlerV1.OptionalAuthMiddleware(), handlerV1.GetReadingListPosts)
+	apiV1.POST("/reading-lists/:id/posts", handlerV1.AuthMiddleware(), handlerV1.AddReadingListPost)
+	apiV1.DELETE("/reading-lists/:id/posts/:post_id", handlerV1.AuthMiddleware(), handlerV1.RemoveReadingListPost)
+	apiV1.PUT("/reading-lists/:id/posts/order", handlerV1.AuthMiddleware(), handlerV1.ReorderReadingList)
+
+	apiV1.GET("/tags", handlerV1.GetAllTags)
+	apiV1.GET("/tags/:slug/posts", handlerV1.OptionalAuthMiddleware(), handlerV1.GetTagPosts)
 
-	apiV1.POST("/posts", handlerV1.CreatePost)
+	apiV1.GET("/search", handlerV1.OptionalAuthMiddleware(), handlerV1.Search)
 
-	apiV1.POST("/categories", handlerV1.CreateCategory)
+	apiV1.POST("/media", handlerV1.AuthMiddleware(), handlerV1.UploadMedia)
+	apiV1.GET("/media/:id", handlerV1.GetMedia)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
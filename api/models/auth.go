@@ -28,10 +28,24 @@ type VerifyRequest struct {
 	Code  string `json:"code" binding:"required"`
 }
 
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
 type UpdatePasswordRequest struct {
-	Password string `json:"password" binding:"required"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type AuthPayload struct {
+	ID        string `json:"id"`
+	UserID    int64  `json:"user_id"`
+	Email     string `json:"email"`
+	UserType  string `json:"user_type"`
+	IssuedAt  string `json:"issued_at"`
+	ExpiredAt string `json:"expired_at"`
 }
@@ -10,6 +10,10 @@ type CreateCategoryRequest struct {
 	Title string `json:"title" binding:"required,max=100"`
 }
 
+type UpdateCategoryRequest struct {
+	Title string `json:"title" binding:"required,max=100"`
+}
+
 type GetAllCategoriesResponse struct {
 	Categories []*Category `json:"categories"`
 	Count      int32       `json:"count"`
@@ -0,0 +1,33 @@
+package models
+
+type Comment struct {
+	ID           int64      `json:"id"`
+	PostID       int64      `json:"post_id"`
+	UserID       int64      `json:"user_id"`
+	ParentID     int64      `json:"parent_id"`
+	Description  string     `json:"description"`
+	CreatedAt    string     `json:"created_at"`
+	UpdatedAt    string     `json:"updated_at"`
+	RepliesCount int32      `json:"replies_count"`
+	Replies      []*Comment `json:"replies,omitempty"`
+}
+
+type CreateCommentRequest struct {
+	Description string `json:"description" binding:"required,max=2000"`
+	ParentID    int64  `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Description string `json:"description" binding:"required,max=2000"`
+}
+
+type GetAllCommentsParams struct {
+	Limit  int32  `json:"limit" default:"10"`
+	Cursor string `json:"cursor"`
+}
+
+type GetAllCommentsResponse struct {
+	Comments   []*Comment `json:"comments"`
+	Count      int32      `json:"count"`
+	NextCursor string     `json:"next_cursor"`
+}
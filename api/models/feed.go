@@ -0,0 +1,9 @@
+package models
+
+type FeedResponse struct {
+	Posts      []*Post `json:"posts"`
+	NextCursor string  `json:"next_cursor"`
+	// Fallback is set when the user follows nobody and the feed shows
+	// recent posts from everyone instead.
+	Fallback bool `json:"fallback"`
+}
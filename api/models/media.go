@@ -0,0 +1,17 @@
+package models
+
+type Media struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+type GetMediaParams struct {
+	Width  int    `json:"width" example:"640"`
+	Height int    `json:"height" example:"320"`
+	Fit    string `json:"fit" enums:"cover,contain,fill" default:"cover"`
+	Format string `json:"format" enums:"jpeg,png,webp"`
+}
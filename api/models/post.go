@@ -1,16 +1,45 @@
 package models
 
+// Post is returned with its full Markdown body only by the single post
+// routes; lists carry just the excerpt. DescriptionHTML and TOC are set when
+// the post is requested with render=html.
 type Post struct {
-	ID          int64        `json:"id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	ImageUrl    string       `json:"image_url"`
-	UserID      int64        `json:"user_id"`
-	CategoryID  int64        `json:"category_id"`
-	UpdatedAt   string       `json:"updated_at"`
-	ViewsCount  int32        `json:"views_count"`
-	CreatedAt   string       `json:"created_at"`
-	LikeInfo    PostLikeInfo `json:"like_info"`
+	ID                 int64        `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description,omitempty"`
+	Excerpt            string       `json:"excerpt"`
+	WordCount          int          `json:"word_count"`
+	ReadingTimeMinutes int          `json:"reading_time_minutes"`
+	ImageUrl           string       `json:"image_url"`
+	UserID             int64        `json:"user_id"`
+	CategoryID         int64        `json:"category_id"`
+	UpdatedAt          string       `json:"updated_at"`
+	ViewsCount         int32        `json:"views_count"`
+	CreatedAt          string       `json:"created_at"`
+	Status             string       `json:"status" enums:"draft,published,scheduled,archived"`
+	PublishAt          string       `json:"publish_at"`
+	Tags               []string     `json:"tags"`
+	LikeInfo           PostLikeInfo `json:"like_info"`
+	MyReaction         string       `json:"my_reaction" enums:"like,dislike"`
+	Bookmarked         bool         `json:"bookmarked"`
+	Author             *PostAuthor  `json:"author,omitempty"`
+	Category           *Category    `json:"category,omitempty"`
+	DescriptionHTML    string       `json:"description_html,omitempty"`
+	TOC                []TOCEntry   `json:"toc,omitempty"`
+}
+
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type PostAuthor struct {
+	ID              int64  `json:"id"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Username        string `json:"username"`
+	ProfileImageUrl string `json:"profile_image_url"`
 }
 
 type PostLikeInfo struct {
@@ -19,10 +48,25 @@ type PostLikeInfo struct {
 }
 
 type CreatePostRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ImageUrl    string `json:"image_url"`
-	CategoryID  int64  `json:"category_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	ImageUrl    string   `json:"image_url"`
+	CategoryID  int64    `json:"category_id"`
+	Status      string   `json:"status" binding:"omitempty,oneof=draft published scheduled" enums:"draft,published,scheduled" default:"published"`
+	PublishAt   string   `json:"publish_at" binding:"required_if=Status scheduled" example:"2023-01-01T09:00:00Z"`
+	Tags        []string `json:"tags" example:"golang,backend"`
+}
+
+type UpdatePostRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	ImageUrl    string   `json:"image_url"`
+	CategoryID  int64    `json:"category_id"`
+	Tags        []string `json:"tags" example:"golang,backend"`
+}
+
+type SchedulePostRequest struct {
+	PublishAt string `json:"publish_at" binding:"required" example:"2023-01-01T09:00:00Z"`
 }
 
 type GetAllPostsParams struct {
@@ -31,7 +75,14 @@ type GetAllPostsParams struct {
 	Search     string `json:"search"`
 	UserID     int64  `json:"user_id"`
 	CategoryID int64  `json:"category_id"`
-	SortByData string `json:"sort_by_date" enums:"asc,desc" default:"desc"`
+	FromDate   string `json:"from_date" example:"2022-12-01"`
+	ToDate     string `json:"to_date" example:"2022-12-31"`
+	SortBy     string `json:"sort_by" enums:"date,views,likes" default:"date"`
+	Order      string `json:"order" enums:"asc,desc" default:"desc"`
+	// Deprecated: same as sort_by=date with this order
+	SortByDate string `json:"sort_by_date" enums:"asc,desc"`
+	Status     string `json:"status" enums:"draft,published,scheduled,archived" default:"published"`
+	Tag        string `json:"tag"`
 }
 
 type GetAllPostsResponse struct {
@@ -0,0 +1,42 @@
+package models
+
+type ReadingList struct {
+	ID         int64  `json:"id"`
+	UserID     int64  `json:"user_id"`
+	Name       string `json:"name"`
+	IsDefault  bool   `json:"is_default"`
+	IsPublic   bool   `json:"is_public"`
+	PostsCount int32  `json:"posts_count"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type CreateReadingListRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	IsPublic bool   `json:"is_public"`
+}
+
+// UpdateReadingListRequest changes only the fields present in the body.
+type UpdateReadingListRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=100"`
+	IsPublic *bool   `json:"is_public"`
+}
+
+type AddReadingListPostRequest struct {
+	PostID int64 `json:"post_id" binding:"required"`
+}
+
+type ReorderReadingListRequest struct {
+	PostIDs []int64 `json:"post_ids" binding:"required"`
+}
+
+type GetAllReadingListsParams struct {
+	Limit  int32 `json:"limit" binding:"required" default:"10"`
+	Page   int32 `json:"page" binding:"required" default:"1"`
+	UserID int64 `json:"user_id"`
+}
+
+type GetAllReadingListsResponse struct {
+	Lists []*ReadingList `json:"lists"`
+	Count int32          `json:"count"`
+}
@@ -0,0 +1,57 @@
+package models
+
+// SearchParams pages every section with page unless the section has a page
+// of its own.
+type SearchParams struct {
+	Q              string `json:"q" binding:"required"`
+	Type           string `json:"type" enums:"users,posts,categories" example:"users,posts"`
+	Limit          int32  `json:"limit" default:"10"`
+	Page           int32  `json:"page" default:"1"`
+	UsersPage      int32  `json:"users_page"`
+	PostsPage      int32  `json:"posts_page"`
+	CategoriesPage int32  `json:"categories_page"`
+}
+
+// SearchResponse has a section per searched type. A section that could not
+// be searched is left out and a warning is added instead.
+type SearchResponse struct {
+	Users      *SearchUsersSection      `json:"users,omitempty"`
+	Posts      *SearchPostsSection      `json:"posts,omitempty"`
+	Categories *SearchCategoriesSection `json:"categories,omitempty"`
+	Warnings   []string                 `json:"warnings,omitempty"`
+}
+
+type SearchUsersSection struct {
+	Items []*SearchUserResult `json:"items"`
+	Count int32               `json:"count"`
+	Page  int32               `json:"page"`
+}
+
+type SearchPostsSection struct {
+	Items []*SearchPostResult `json:"items"`
+	Count int32               `json:"count"`
+	Page  int32               `json:"page"`
+}
+
+type SearchCategoriesSection struct {
+	Items []*SearchCategoryResult `json:"items"`
+	Count int32                   `json:"count"`
+	Page  int32                   `json:"page"`
+}
+
+// Highlights are HTML escaped with matched terms wrapped in <mark>.
+type SearchUserResult struct {
+	User      *PostAuthor `json:"user"`
+	Highlight string      `json:"highlight" example:"<mark>John</mark> Doe (@john)"`
+}
+
+type SearchPostResult struct {
+	Post             *Post  `json:"post"`
+	TitleHighlight   string `json:"title_highlight"`
+	ExcerptHighlight string `json:"excerpt_highlight"`
+}
+
+type SearchCategoryResult struct {
+	Category  *Category `json:"category"`
+	Highlight string    `json:"highlight"`
+}
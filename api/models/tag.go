@@ -0,0 +1,11 @@
+package models
+
+type Tag struct {
+	Slug       string `json:"slug"`
+	PostsCount int64  `json:"posts_count"`
+}
+
+type GetAllTagsResponse struct {
+	Tags  []*Tag `json:"tags"`
+	Count int32  `json:"count"`
+}
@@ -1,37 +1,66 @@
 package models
 
+// User is returned in one of three views. The public view, for anonymous
+// callers and other users, has the profile fields only. The self view, for
+// the user themselves, and the admin view, for superadmins, add the
+// private fields.
 type User struct {
 	ID              int64  `json:"id"`
 	FirstName       string `json:"first_name"`
 	LastName        string `json:"last_name"`
-	PhoneNumber     string `json:"phone_number"`
-	Email           string `json:"email"`
-	Gender          string `json:"gender"`
 	Username        string `json:"username"`
 	ProfileImageUrl string `json:"profile_image_url"`
-	Type            string `json:"type"`
 	CreatedAt       string `json:"created_at"`
+	FollowersCount  int64  `json:"followers_count"`
+	FollowingCount  int64  `json:"following_count"`
+
+	Email         string `json:"email,omitempty"`          // Self and admin views only
+	PhoneNumber   string `json:"phone_number,omitempty"`   // Self and admin views only
+	PhoneVerified bool   `json:"phone_verified,omitempty"` // Self and admin views only
+	Gender        string `json:"gender,omitempty"`         // Self and admin views only
+	Type          string `json:"type,omitempty"`           // Self and admin views only
+	DeletedAt     string `json:"deleted_at,omitempty"`     // Self and admin views only
 }
 
 type CreateUserRequest struct {
 	FirstName       string `json:"first_name" binding:"required,min=2,max=30"`
 	LastName        string `json:"last_name" binding:"required,min=2,max=30"`
-	PhoneNumber     string `json:"phone_number"`
+	PhoneNumber     string `json:"phone_number" binding:"omitempty,phone"`
 	Email           string `json:"email" binding:"required,email"`
 	Gender          string `json:"gender" binding:"oneof=male female"`
 	Username        string `json:"username"`
 	ProfileImageUrl string `json:"profile_image_url"`
-	Type            string `json:"type" binding:"required,oneof=superadmin user"`
+	Type            string `json:"type" binding:"omitempty,oneof=superadmin user"`
 	Password        string `json:"password" binding:"required,min=6,max=16"`
 }
 
+// UpdateUserRequest changes only the fields present in the body.
 type UpdateUserRequest struct {
-	FirstName       string `json:"first_name" binding:"required,min=2,max=30"`
-	LastName        string `json:"last_name" binding:"required,min=2,max=30"`
-	PhoneNumber     string `json:"phone_number"`
-	Gender          string `json:"gender" binding:"oneof=male female"`
-	Username        string `json:"username"`
-	ProfileImageUrl string `json:"profile_image_url"`
+	FirstName       *string `json:"first_name" binding:"omitempty,min=2,max=30"`
+	LastName        *string `json:"last_name" binding:"omitempty,min=2,max=30"`
+	PhoneNumber     *string `json:"phone_number" binding:"omitempty,phone"`
+	Gender          *string `json:"gender" binding:"omitempty,oneof=male female"`
+	Username        *string `json:"username"`
+	ProfileImageUrl *string `json:"profile_image_url"`
+}
+
+type UsernameAvailableResponse struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type UserDeletionResponse struct {
+	DeletedAt     string `json:"deleted_at"`
+	RestoreBefore string `json:"restore_before"`
+}
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DeleteMeRequest struct {
+	Password string `json:"password" binding:"required"`
 }
 
 type GetAllUsersResponse struct {
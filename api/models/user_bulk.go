@@ -0,0 +1,22 @@
+package models
+
+// ImportUsersResponse reports the outcome of every row of an import. In a
+// dry run nothing is created and valid rows are reported as such.
+type ImportUsersResponse struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Valid   int              `json:"valid"`
+	Failed  int              `json:"failed"`
+	Rows    []*ImportUserRow `json:"rows"`
+}
+
+type ImportUserRow struct {
+	// Row is the 1-based number of the record in the file. The CSV header
+	// and blank NDJSON lines are not counted.
+	Row    int    `json:"row"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status" enums:"created,valid,failed"`
+	ID     int64  `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
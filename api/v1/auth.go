@@ -5,7 +5,10 @@ import (
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
 	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
+	"strings"
 )
 
 // @Router /auth/register [post]
@@ -28,10 +31,7 @@ func (h *handlerV1) Register(c *gin.Context) {
 		return
 	}
 
-	user, _ := h.grpcClient.UserService().GetByEmail(context.Background(), &pbu.GetByEmailRequest{
-		Email: req.Email,
-	})
-	if user != nil {
+	if h.isRegistered(req.Email) {
 		c.JSON(http.StatusBadRequest, errorResponse(ErrEmailExists))
 		return
 	}
@@ -46,6 +46,7 @@ func (h *handlerV1) Register(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	h.codeSent(verificationKey(req.Email))
 
 	c.JSON(http.StatusOK, models.ResponseOK{
 		Message: "success",
@@ -54,12 +55,18 @@ func (h *handlerV1) Register(c *gin.Context) {
 
 // @Router /auth/verify [post]
 // @Summary Verify user
-// @Description Verify user
+// @Description Verify the email of a registered user with the code sent to it.
+// @Description After too many incorrect attempts the code stops working and a new one has to be requested
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param data body models.VerifyRequest true "Data"
-// @Success 200 {object} models.AuthResponse
+// @Success 201 {object} models.AuthResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) Verify(c *gin.Context) {
 	var (
@@ -72,14 +79,34 @@ func (h *handlerV1) Verify(c *gin.Context) {
 		return
 	}
 
+	key := verificationKey(req.Email)
+	if wait := h.codeAttempts.Attempt(key); wait > 0 {
+		setRetryAfter(c, wait)
+		c.JSON(http.StatusTooManyRequests, errorResponse(ErrCodeAttempts))
+		return
+	}
+
 	result, err := h.grpcClient.AuthService().Verify(context.Background(), &pbu.VerifyRegisterRequest{
 		Email: req.Email,
 		Code:  req.Code,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse(err))
+		switch status.Code(err) {
+		case codes.AlreadyExists:
+			c.JSON(http.StatusConflict, errorResponse(ErrAlreadyVerified))
+		case codes.NotFound:
+			// the pending registration is gone once the user is verified
+			if h.isRegistered(req.Email) {
+				c.JSON(http.StatusConflict, errorResponse(ErrAlreadyVerified))
+				return
+			}
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		default:
+			h.codeError(c, key, err)
+		}
 		return
 	}
+	h.codeAttempts.Reset(key)
 
 	c.JSON(http.StatusCreated, models.AuthResponse{
 		ID:          result.Id,
@@ -92,6 +119,63 @@ func (h *handlerV1) Verify(c *gin.Context) {
 	})
 }
 
+// @Router /auth/verify/resend [post]
+// @Summary Resend verification code
+// @Description Send a new verification code to a registered but not yet verified email.
+// @Description The previous code stops working. A new code can be requested once per cooldown
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body models.ResendVerificationRequest true "Data"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ResendVerification(c *gin.Context) {
+	var (
+		req models.ResendVerificationRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if wait := h.resendCooldown.Remaining(verificationKey(req.Email)); wait > 0 {
+		setRetryAfter(c, wait)
+		c.JSON(http.StatusTooManyRequests, errorResponse(ErrResendTooSoon))
+		return
+	}
+
+	if h.isRegistered(req.Email) {
+		c.JSON(http.StatusConflict, errorResponse(ErrAlreadyVerified))
+		return
+	}
+
+	_, err = h.grpcClient.AuthService().ResendVerification(context.Background(), &pbu.ResendVerificationRequest{
+		Email: req.Email,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		case codes.AlreadyExists:
+			c.JSON(http.StatusConflict, errorResponse(ErrAlreadyVerified))
+		default:
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	h.codeSent(verificationKey(req.Email))
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "Verification code has been sent",
+	})
+}
+
 // @Router /auth/login [post]
 // @Summary Login user
 // @Description Login user
@@ -100,6 +184,8 @@ func (h *handlerV1) Verify(c *gin.Context) {
 // @Produce json
 // @Param data body models.LoginRequest true "Data"
 // @Success 200 {object} models.AuthResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) Login(c *gin.Context) {
 	var (
@@ -117,7 +203,14 @@ func (h *handlerV1) Login(c *gin.Context) {
 		Password: req.Password,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse(err))
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			c.JSON(http.StatusForbidden, errorResponse(ErrUserNotVerified))
+		case codes.NotFound, codes.InvalidArgument, codes.Unauthenticated:
+			c.JSON(http.StatusBadRequest, errorResponse(ErrWrongEmailOrPass))
+		default:
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
@@ -164,3 +257,43 @@ func (h *handlerV1) VerifyForgotPassword(c *gin.Context) {
 		Message: "Validation code has been sent",
 	})
 }
+
+// verificationKey identifies the pending verification of an email in the
+// limiters.
+func verificationKey(email string) string {
+	return "verify:" + strings.ToLower(email)
+}
+
+// codeError responds to a failed check of a verification code. The attempt
+// was already counted against key before the check.
+func (h *handlerV1) codeError(c *gin.Context, key string, err error) {
+	switch {
+	case statusReason(err) == reasonCodeExpired:
+		c.JSON(http.StatusGone, errorResponse(ErrCodeExpired))
+	case status.Code(err) == codes.InvalidArgument:
+		// the last attempt starts the lockout
+		if wait := h.codeAttempts.Check(key); wait > 0 {
+			setRetryAfter(c, wait)
+			c.JSON(http.StatusTooManyRequests, errorResponse(ErrCodeAttempts))
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse(ErrIncorrectCode))
+	default:
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+// codeSent starts the resend cooldown of key and gives its new code a fresh
+// set of attempts.
+func (h *handlerV1) codeSent(key string) {
+	h.resendCooldown.Start(key)
+	h.codeAttempts.Reset(key)
+}
+
+func (h *handlerV1) isRegistered(email string) bool {
+	user, _ := h.grpcClient.UserService().GetByEmail(context.Background(), &pbu.GetByEmailRequest{
+		Email: email,
+	})
+
+	return user != nil
+}
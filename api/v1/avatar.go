@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultAvatarSize = 128
+
+// @Router /users/{id}/avatar.svg [get]
+// @Summary Get generated avatar
+// @Description Get the default avatar of a user: their initials, or an identicon when the name has no letters.
+// @Description The avatar depends only on the user id and name, so it can be cached and revalidated with the ETag
+// @Tags user
+// @Produce image/svg+xml
+// @Param id path int true "ID"
+// @Param style query string false "Style, the configured one by default" Enums(initials, identicon)
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAvatarSVG(c *gin.Context) {
+	h.getAvatar(c, false)
+}
+
+// @Router /users/{id}/avatar.png [get]
+// @Summary Get generated avatar as PNG
+// @Description Same as the SVG avatar, drawn as a square PNG. The size must be one of the preset media sizes.
+// @Description Initials in scripts the bundled font does not cover, e.g. Arabic or CJK, are drawn as an identicon
+// @Tags user
+// @Produce image/png
+// @Param id path int true "ID"
+// @Param style query string false "Style, the configured one by default" Enums(initials, identicon)
+// @Param size query int false "Width and height" default(128)
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAvatarPNG(c *gin.Context) {
+	h.getAvatar(c, true)
+}
+
+func (h *handlerV1) getAvatar(c *gin.Context, asPNG bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	style, err := h.avatars.Style(c.Query("style"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// SVG avatars scale, so their key has no size
+	size := 0
+	if asPNG {
+		size = defaultAvatarSize
+		if c.Query("size") != "" {
+			size, err = strconv.Atoi(c.Query("size"))
+			if err != nil || !h.isPresetSize(size) {
+				c.JSON(http.StatusBadRequest, errorResponse(ErrInvalidMediaSize))
+				return
+			}
+		}
+	}
+
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: int64(id)})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if isHiddenUser(user, userViewFor(c, user.Id)) {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	name := user.FirstName + " " + user.LastName
+	key := h.avatars.Key(user.Id, name, style, size)
+
+	// the avatar changes with the name, so clients revalidate instead of
+	// keeping it forever like uploaded media
+	etag := `"` + key + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=3600")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if !asPNG {
+		c.Data(http.StatusOK, "image/svg+xml", h.avatars.SVG(user.Id, name, style))
+		return
+	}
+
+	cacheKey := key + ".png"
+	if data, ok := h.avatarCache.Get(cacheKey); ok {
+		c.Data(http.StatusOK, "image/png", data)
+		return
+	}
+
+	data, err := h.avatars.PNG(user.Id, name, style, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.avatarCache.Put(cacheKey, data)
+	if err != nil {
+		log.Printf("failed to cache avatar %s: %v", cacheKey, err)
+	}
+
+	c.Data(http.StatusOK, "image/png", data)
+}
+
+// profileImageURL falls back to the generated avatar for users that have
+// not set a profile image. Only profile responses use it; exports, search
+// results and expanded authors carry the stored value.
+func (h *handlerV1) profileImageURL(user *pbu.User) string {
+	if user.ProfileImageUrl != "" {
+		return user.ProfileImageUrl
+	}
+
+	return fmt.Sprintf("%s/v1/users/%d/avatar.svg", strings.TrimRight(h.cfg.MediaPublicURL, "/"), user.Id)
+}
@@ -5,9 +5,13 @@ import (
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
 	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
+	"strconv"
 )
 
+// @Security ApiKeyAuth
 // @Router /categories [post]
 // @Summary Create a category
 // @Description Create a category
@@ -16,6 +20,7 @@ import (
 // @Produce json
 // @Param category body models.CreateCategoryRequest true "Category"
 // @Success 201 {object} models.Category
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) CreateCategory(c *gin.Context) {
 	var (
@@ -36,9 +41,204 @@ func (h *handlerV1) CreateCategory(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.Category{
-		ID:        resp.Id,
-		Title:     resp.Title,
-		CreatedAt: resp.CreatedAt,
+	c.JSON(http.StatusCreated, parseCategoryModel(resp))
+}
+
+func parseCategoryModel(category *pbp.Category) *models.Category {
+	return &models.Category{
+		ID:        category.Id,
+		Title:     category.Title,
+		CreatedAt: category.CreatedAt,
+	}
+}
+
+// @Router /categories/{id} [get]
+// @Summary Get category by id
+// @Description Get category by id
+// @Tags category
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Category
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	resp, err := h.grpcClient.CategoryService().Get(context.Background(), &pbp.GetCategoryRequest{Id: int64(id)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, parseCategoryModel(resp))
+}
+
+// @Router /categories [get]
+// @Summary Get all categories
+// @Description Get all categories
+// @Tags category
+// @Accept json
+// @Produce json
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllCategoriesResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAllCategories(c *gin.Context) {
+	req, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.CategoryService().GetAll(context.Background(), &pbp.GetAllCategoriesRequest{
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Search: req.Search,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, getCategoriesResponse(result))
+}
+
+func getCategoriesResponse(data *pbp.GetAllCategoriesResponse) *models.GetAllCategoriesResponse {
+	response := models.GetAllCategoriesResponse{
+		Categories: make([]*models.Category, 0),
+		Count:      data.Count,
+	}
+
+	for _, category := range data.Categories {
+		response.Categories = append(response.Categories, parseCategoryModel(category))
+	}
+
+	return &response
+}
+
+// @Security ApiKeyAuth
+// @Router /categories/{id} [put]
+// @Summary Update a category
+// @Description Update a category
+// @Tags category
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param category body models.UpdateCategoryRequest true "Category"
+// @Success 200 {object} models.Category
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UpdateCategory(c *gin.Context) {
+	var (
+		req models.UpdateCategoryRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	resp, err := h.grpcClient.CategoryService().Update(context.Background(), &pbp.Category{
+		Id:    int64(id),
+		Title: req.Title,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, parseCategoryModel(resp))
+}
+
+// @Security ApiKeyAuth
+// @Router /categories/{id} [delete]
+// @Summary Delete a category
+// @Description Delete a category. Refused while posts still reference it
+// @Tags category
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DeleteCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	inUse, err := h.isCategoryInUse(int64(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if inUse {
+		c.JSON(http.StatusConflict, errorResponse(ErrCategoryInUse))
+		return
+	}
+
+	_, err = h.grpcClient.CategoryService().Delete(context.Background(), &pbp.GetCategoryRequest{Id: int64(id)})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		case codes.FailedPrecondition:
+			// a post was attached after the check above
+			c.JSON(http.StatusConflict, errorResponse(ErrCategoryInUse))
+		default:
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
 	})
 }
+
+// isCategoryInUse reports whether any post references the category. The
+// post service lists published posts only unless a status is given, so every
+// status is asked for.
+func (h *handlerV1) isCategoryInUse(id int64) (bool, error) {
+	for _, postStatus := range []string{postStatusPublished, postStatusDraft, postStatusScheduled, postStatusArchived} {
+		posts, err := h.grpcClient.PostService().GetAll(context.Background(), &pbp.GetAllPostsRequest{
+			Limit:      1,
+			Page:       1,
+			CategoryId: id,
+			Status:     postStatus,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if posts.Count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
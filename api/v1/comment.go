@@ -0,0 +1,416 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// repliesPreviewLimit is the number of replies embedded under every
+// top-level comment; the rest are paged through /comments/{id}/replies.
+const repliesPreviewLimit = 3
+
+var ErrNestedReply = errors.New("replies can only be added to top-level comments")
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/comments [post]
+// @Summary Create a comment
+// @Description Create a comment on a post, or a reply when parent_id is set
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param comment body models.CreateCommentRequest true "Comment"
+// @Success 201 {object} models.Comment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) CreateComment(c *gin.Context) {
+	var (
+		req models.CreateCommentRequest
+	)
+
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.getVisiblePost(c, int64(postID))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.ParentID != 0 {
+		parent, err := h.grpcClient.CommentService().Get(context.Background(), &pbp.GetCommentRequest{Id: req.ParentID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if parent.PostId != int64(postID) || parent.ParentId != 0 {
+			c.JSON(http.StatusBadRequest, errorResponse(ErrNestedReply))
+			return
+		}
+	}
+
+	resp, err := h.grpcClient.CommentService().Create(context.Background(), &pbp.Comment{
+		PostId:      int64(postID),
+		UserId:      payload.UserID,
+		ParentId:    req.ParentID,
+		Description: req.Description,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, parseCommentModel(resp))
+}
+
+// @Router /posts/{id}/comments [get]
+// @Summary Get post comments
+// @Description Get top-level comments of a post with a preview of their replies
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param filter query models.GetAllCommentsParams false "Filter"
+// @Success 200 {object} models.GetAllCommentsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAllComments(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := validateCursorParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err = h.getVisiblePost(c, int64(postID))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.CommentService().GetAll(context.Background(), &pbp.GetAllCommentsRequest{
+		PostId: int64(postID),
+		Limit:  params.Limit,
+		Cursor: params.Cursor,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := getCommentsResponse(result)
+	err = h.fillRepliesPreview(response.Comments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// fillRepliesPreview attaches the first replies to every comment that has
+// any. The lookups run on at most expandWorkers goroutines.
+func (h *handlerV1) fillRepliesPreview(comments []*models.Comment) error {
+	var (
+		queue    = make(chan *models.Comment)
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	workers := expandWorkers
+	if len(comments) < workers {
+		workers = len(comments)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for comment := range queue {
+				replies, err := h.grpcClient.CommentService().GetAll(context.Background(), &pbp.GetAllCommentsRequest{
+					PostId:   comment.PostID,
+					ParentId: comment.ID,
+					Limit:    repliesPreviewLimit,
+				})
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				// every comment is written by one worker only
+				comment.Replies = getCommentsResponse(replies).Comments
+			}
+		}()
+	}
+
+	for _, comment := range comments {
+		if comment.RepliesCount > 0 {
+			queue <- comment
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return firstErr
+}
+
+// @Router /comments/{id}/replies [get]
+// @Summary Get comment replies
+// @Description Get replies of a top-level comment
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Param filter query models.GetAllCommentsParams false "Filter"
+// @Success 200 {object} models.GetAllCommentsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetCommentReplies(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := validateCursorParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	comment, err := h.grpcClient.CommentService().Get(context.Background(), &pbp.GetCommentRequest{Id: int64(id)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = h.getVisiblePost(c, comment.PostId)
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.CommentService().GetAll(context.Background(), &pbp.GetAllCommentsRequest{
+		PostId:   comment.PostId,
+		ParentId: comment.Id,
+		Limit:    params.Limit,
+		Cursor:   params.Cursor,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, getCommentsResponse(result))
+}
+
+// @Security ApiKeyAuth
+// @Router /comments/{id} [put]
+// @Summary Update a comment
+// @Description Update a comment. Only its author can edit it
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Param comment body models.UpdateCommentRequest true "Comment"
+// @Success 200 {object} models.Comment
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UpdateComment(c *gin.Context) {
+	var (
+		req models.UpdateCommentRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	comment, err := h.grpcClient.CommentService().Get(context.Background(), &pbp.GetCommentRequest{Id: int64(id)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if comment.UserId != payload.UserID {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+		return
+	}
+
+	comment.Description = req.Description
+	resp, err := h.grpcClient.CommentService().Update(context.Background(), comment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, parseCommentModel(resp))
+}
+
+// @Security ApiKeyAuth
+// @Router /comments/{id} [delete]
+// @Summary Delete a comment
+// @Description Delete a comment. Allowed for its author, the post author and superadmins
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DeleteComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	comment, err := h.grpcClient.CommentService().Get(context.Background(), &pbp.GetCommentRequest{Id: int64(id)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if comment.UserId != payload.UserID && payload.UserType != userTypeSuperadmin {
+		post, err := h.grpcClient.PostService().Get(context.Background(), &pbp.GetPostRequest{Id: comment.PostId})
+		if err != nil {
+			if isNotFound(err) {
+				c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if post.UserId != payload.UserID {
+			c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+			return
+		}
+	}
+
+	_, err = h.grpcClient.CommentService().Delete(context.Background(), &pbp.GetCommentRequest{Id: int64(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+func parseCommentModel(comment *pbp.Comment) models.Comment {
+	return models.Comment{
+		ID:           comment.Id,
+		PostID:       comment.PostId,
+		UserID:       comment.UserId,
+		ParentID:     comment.ParentId,
+		Description:  comment.Description,
+		CreatedAt:    comment.CreatedAt,
+		UpdatedAt:    comment.UpdatedAt,
+		RepliesCount: comment.RepliesCount,
+	}
+}
+
+func getCommentsResponse(data *pbp.GetAllCommentsResponse) *models.GetAllCommentsResponse {
+	response := models.GetAllCommentsResponse{
+		Comments:   make([]*models.Comment, 0),
+		Count:      data.Count,
+		NextCursor: data.NextCursor,
+	}
+
+	for _, comment := range data.Comments {
+		cm := parseCommentModel(comment)
+		response.Comments = append(response.Comments, &cm)
+	}
+
+	return &response
+}
@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Router /users/me/restore [post]
+// @Summary Restore current user
+// @Description Undo the deletion of the authenticated user while the restore window is open
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) RestoreMe(c *gin.Context) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	h.restoreUser(c, payload.UserID)
+}
+
+// @Security ApiKeyAuth
+// @Router /users/{id}/restore [post]
+// @Summary Restore user
+// @Description Undo the deletion of a user while the restore window is open.
+// @Description Users can restore themselves, superadmins can restore anyone
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) RestoreUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	if payload.UserID != int64(id) && payload.UserType != userTypeSuperadmin {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+		return
+	}
+
+	h.restoreUser(c, int64(id))
+}
+
+// @Security ApiKeyAuth
+// @Router /users/pending-deletion [get]
+// @Summary Get users pending deletion
+// @Description Get soft deleted users that have not been purged yet. Superadmins only
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllUsersResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetPendingDeletions(c *gin.Context) {
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.UserService().GetAll(context.Background(), &pbu.GetAllUsersRequest{
+		Limit:       params.Limit,
+		Page:        params.Page,
+		Search:      params.Search,
+		DeletedOnly: true,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.getUsersResponse(c, result))
+}
+
+// deleteUser soft deletes the user and hides their posts. Deleting a user
+// twice only hides the posts again, so a failed call can be retried.
+func (h *handlerV1) deleteUser(c *gin.Context, id int64) {
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: id})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.DeletedAt == "" {
+		user, err = h.grpcClient.UserService().SoftDelete(context.Background(), &pbu.IdRequest{Id: id})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	_, err = h.grpcClient.PostService().SetAuthorHidden(context.Background(), &pbp.SetAuthorHiddenRequest{
+		UserId: id,
+		Hidden: true,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := models.UserDeletionResponse{
+		DeletedAt: user.DeletedAt,
+	}
+	if deletedAt, err := time.Parse(time.RFC3339, user.DeletedAt); err == nil {
+		response.RestoreBefore = deletedAt.Add(h.cfg.AccountDeletionWindow).UTC().Format(time.RFC3339)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// restoreUser restores the user and shows their posts again. Restoring a
+// user that is not deleted only shows the posts again, so a failed call can
+// be retried.
+func (h *handlerV1) restoreUser(c *gin.Context, id int64) {
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: id})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.DeletedAt != "" {
+		// the purge job may not have run yet
+		deletedAt, err := time.Parse(time.RFC3339, user.DeletedAt)
+		if err != nil || time.Since(deletedAt) > h.cfg.AccountDeletionWindow {
+			c.JSON(http.StatusGone, errorResponse(ErrRestoreExpired))
+			return
+		}
+
+		user, err = h.grpcClient.UserService().Restore(context.Background(), &pbu.IdRequest{Id: id})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	_, err = h.grpcClient.PostService().SetAuthorHidden(context.Background(), &pbp.SetAuthorHiddenRequest{
+		UserId: id,
+		Hidden: false,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userResponse(user, userViewFor(c, user.Id)))
+}
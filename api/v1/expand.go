@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	expandAuthor   = "author"
+	expandCategory = "category"
+
+	// expandWorkers bounds the number of concurrent lookups per request.
+	expandWorkers = 8
+)
+
+type postExpand struct {
+	author   bool
+	category bool
+}
+
+func parseExpandParam(c *gin.Context) (*postExpand, error) {
+	expand := postExpand{}
+
+	for _, value := range strings.Split(c.Query("expand"), ",") {
+		switch strings.TrimSpace(value) {
+		case "":
+		case expandAuthor:
+			expand.author = true
+		case expandCategory:
+			expand.category = true
+		default:
+			return nil, fmt.Errorf("unknown expand value: %s", value)
+		}
+	}
+
+	return &expand, nil
+}
+
+type expandJob struct {
+	kind string
+	id   int64
+}
+
+// expandPosts embeds authors and categories into the posts. Every distinct
+// id is looked up once; a failed lookup leaves that field empty.
+func (h *handlerV1) expandPosts(expand *postExpand, posts ...*models.Post) {
+	if expand == nil || (!expand.author && !expand.category) {
+		return
+	}
+
+	var (
+		jobs       []expandJob
+		seen       = make(map[expandJob]bool)
+		mu         sync.Mutex
+		authors    = make(map[int64]*models.PostAuthor)
+		categories = make(map[int64]*models.Category)
+	)
+
+	for _, post := range posts {
+		if expand.author && post.UserID != 0 {
+			job := expandJob{kind: expandAuthor, id: post.UserID}
+			if !seen[job] {
+				seen[job] = true
+				jobs = append(jobs, job)
+			}
+		}
+		if expand.category && post.CategoryID != 0 {
+			job := expandJob{kind: expandCategory, id: post.CategoryID}
+			if !seen[job] {
+				seen[job] = true
+				jobs = append(jobs, job)
+			}
+		}
+	}
+
+	queue := make(chan expandJob)
+	var wg sync.WaitGroup
+
+	workers := expandWorkers
+	if len(jobs) < workers {
+		workers = len(jobs)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				switch job.kind {
+				case expandAuthor:
+					user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: job.id})
+					if err != nil {
+						continue
+					}
+					mu.Lock()
+					authors[job.id] = h.parsePostAuthor(user)
+					mu.Unlock()
+				case expandCategory:
+					category, err := h.grpcClient.CategoryService().Get(context.Background(), &pbp.GetCategoryRequest{Id: job.id})
+					if err != nil {
+						continue
+					}
+					mu.Lock()
+					categories[job.id] = parseCategoryModel(category)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, post := range posts {
+		if expand.author {
+			post.Author = authors[post.UserID]
+		}
+		if expand.category {
+			post.Category = categories[post.CategoryID]
+		}
+	}
+}
+
+// parsePostAuthor maps the author of a post, where authors without a
+// profile image get the generated avatar.
+func (h *handlerV1) parsePostAuthor(user *pbu.User) *models.PostAuthor {
+	return &models.PostAuthor{
+		ID:              user.Id,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Username:        user.Username,
+		ProfileImageUrl: h.profileImageURL(user),
+	}
+}
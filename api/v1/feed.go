@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Router /feed [get]
+// @Summary Get the home feed
+// @Description Get published posts from followed authors and categories, newest first.
+// @Description When the user follows nobody, recent posts from everyone are returned and fallback is set.
+// @Description Pass next_cursor from the previous page as cursor to get the next one.
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(10)
+// @Param cursor query string false "Cursor"
+// @Param expand query []string false "Embed related resources" collectionFormat(csv) Enums(author, category)
+// @Success 200 {object} models.FeedResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetFeed(c *gin.Context) {
+	params, err := validateCursorParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	expand, err := parseExpandParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	following, err := h.grpcClient.FollowService().GetFollowingIds(context.Background(), &pbu.GetFollowingIdsRequest{
+		UserId: payload.UserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	categories, err := h.grpcClient.CategoryService().GetFollowed(context.Background(), &pbp.GetFollowedCategoriesRequest{
+		UserId: payload.UserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	req := pbp.GetFeedRequest{
+		UserIds: following.Ids,
+		Limit:   params.Limit,
+		Cursor:  params.Cursor,
+	}
+	for _, category := range categories.Categories {
+		req.CategoryIds = append(req.CategoryIds, category.Id)
+	}
+	req.All = len(req.UserIds) == 0 && len(req.CategoryIds) == 0
+
+	result, err := h.grpcClient.PostService().GetFeed(context.Background(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	posts := h.getPostsResponse(&pbp.GetAllPostsResponse{Posts: result.Posts}).Posts
+	err = h.fillPostsLikeInfo(context.Background(), c, posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.fillPostsBookmarked(context.Background(), c, posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	h.expandPosts(expand, posts...)
+
+	c.JSON(http.StatusOK, models.FeedResponse{
+		Posts:      posts,
+		NextCursor: result.NextCursor,
+		Fallback:   req.All,
+	})
+}
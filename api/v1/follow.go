@@ -0,0 +1,261 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Router /users/{id}/follow [post]
+// @Summary Follow a user
+// @Description Follow a user. Following someone twice has no effect
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) FollowUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	if payload.UserID == int64(id) {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrFollowSelf))
+		return
+	}
+
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: int64(id)})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.DeletedAt != "" {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	_, err = h.grpcClient.FollowService().Follow(context.Background(), &pbu.FollowRequest{
+		FollowerId:  payload.UserID,
+		FollowingId: int64(id),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /users/{id}/follow [delete]
+// @Summary Unfollow a user
+// @Description Unfollow a user
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UnfollowUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.grpcClient.FollowService().Unfollow(context.Background(), &pbu.FollowRequest{
+		FollowerId:  payload.UserID,
+		FollowingId: int64(id),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// @Router /users/{id}/followers [get]
+// @Summary Get followers
+// @Description Get users following the user
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllUsersResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetFollowers(c *gin.Context) {
+	h.getFollows(c, true)
+}
+
+// @Router /users/{id}/following [get]
+// @Summary Get followed users
+// @Description Get users the user follows
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllUsersResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetFollowing(c *gin.Context) {
+	h.getFollows(c, false)
+}
+
+func (h *handlerV1) getFollows(c *gin.Context, followers bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	req := pbu.GetFollowsRequest{
+		UserId:         int64(id),
+		Limit:          params.Limit,
+		Page:           params.Page,
+		ExcludeDeleted: true,
+	}
+
+	var result *pbu.GetAllUsersResponse
+	if followers {
+		result, err = h.grpcClient.FollowService().GetFollowers(context.Background(), &req)
+	} else {
+		result, err = h.grpcClient.FollowService().GetFollowing(context.Background(), &req)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.getUsersResponse(c, result))
+}
+
+// @Security ApiKeyAuth
+// @Router /categories/{id}/follow [post]
+// @Summary Follow a category
+// @Description Follow a category to see its posts in the feed
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) FollowCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.grpcClient.CategoryService().Get(context.Background(), &pbp.GetCategoryRequest{Id: int64(id)})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = h.grpcClient.CategoryService().Follow(context.Background(), &pbp.CategoryFollowRequest{
+		UserId:     payload.UserID,
+		CategoryId: int64(id),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /categories/{id}/follow [delete]
+// @Summary Unfollow a category
+// @Description Unfollow a category
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UnfollowCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.grpcClient.CategoryService().Unfollow(context.Background(), &pbp.CategoryFollowRequest{
+		UserId:     payload.UserID,
+		CategoryId: int64(id),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
@@ -2,36 +2,111 @@ package v1
 
 import (
 	"errors"
+	"fmt"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/config"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/avatar"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/brute_force"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/cooldown"
 	grpcPkg "github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/grpc_client"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/markdown"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/media"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/scheduler"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/slug"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/storage"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/view_counter"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
 	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+const dateLayout = "2006-01-02"
+
+// reasonCodeExpired is the ErrorInfo reason the auth service gives when a
+// verification code was correct but has expired.
+const reasonCodeExpired = "CODE_EXPIRED"
+
 var (
 	ErrWrongEmailOrPass = errors.New("wrong email or password")
+	ErrWrongPassword    = errors.New("wrong password")
 	ErrEmailExists      = errors.New("email already exists")
 	ErrUserNotVerified  = errors.New("user not verified")
 	ErrIncorrectCode    = errors.New("incorrect verification code")
 	ErrCodeExpired      = errors.New("verification code has been expired")
+	ErrAlreadyVerified  = errors.New("user is already verified")
+	ErrCodeAttempts     = errors.New("too many incorrect codes, request a new one")
+	ErrResendTooSoon    = errors.New("verification code was sent recently, try again later")
+	ErrNoPhone          = errors.New("add a phone number first")
+	ErrPhoneVerified    = errors.New("phone number is already verified")
 	ErrForbidden        = errors.New("forbidden")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrNotFound         = errors.New("not found")
+	ErrCategoryInUse    = errors.New("category is still used by posts")
+	ErrInvalidPublishAt = errors.New("publish_at must be a future time in RFC3339 format")
+	ErrInvalidTag       = errors.New("tags must contain letters or digits and be at most 50 characters long")
+	ErrDefaultList      = errors.New("the default reading list cannot be renamed or deleted")
+	ErrFollowSelf       = errors.New("you cannot follow yourself")
+	ErrNothingToUpdate  = errors.New("no fields to update")
+	ErrWeakPassword     = errors.New("password must be 6 to 16 characters long and contain a letter and a digit")
+	ErrSamePassword     = errors.New("new password must differ from the current one")
+	ErrTooManyAttempts  = errors.New("too many failed attempts, try again later")
+	ErrRestoreExpired   = errors.New("the restore window is over")
+	ErrAccountDeleted   = errors.New("the account is deleted, restore it first")
 )
 
 type handlerV1 struct {
-	cfg        *config.Config
-	grpcClient grpcPkg.GrpcClientI
+	cfg            *config.Config
+	grpcClient     grpcPkg.GrpcClientI
+	viewCounter    *view_counter.Counter
+	storage        storage.Storage
+	mediaCache     *media.Cache
+	resizer        *media.Resizer
+	publisher      *scheduler.Publisher
+	markdown       *markdown.Renderer
+	bruteForce     *brute_force.Limiter
+	codeAttempts   *brute_force.Limiter
+	resendCooldown *cooldown.Cooldown
+	avatars        *avatar.Generator
+	avatarCache    *media.Cache
 }
 
 type HandlerV1Options struct {
-	Cfg        *config.Config
-	GrpcClient grpcPkg.GrpcClientI
+	Cfg            *config.Config
+	GrpcClient     grpcPkg.GrpcClientI
+	ViewCounter    *view_counter.Counter
+	Storage        storage.Storage
+	MediaCache     *media.Cache
+	Resizer        *media.Resizer
+	Publisher      *scheduler.Publisher
+	Markdown       *markdown.Renderer
+	BruteForce     *brute_force.Limiter
+	CodeAttempts   *brute_force.Limiter
+	ResendCooldown *cooldown.Cooldown
+	Avatars        *avatar.Generator
+	AvatarCache    *media.Cache
 }
 
 func New(options *HandlerV1Options) *handlerV1 {
 	return &handlerV1{
-		cfg:        options.Cfg,
-		grpcClient: options.GrpcClient,
+		cfg:            options.Cfg,
+		grpcClient:     options.GrpcClient,
+		viewCounter:    options.ViewCounter,
+		storage:        options.Storage,
+		mediaCache:     options.MediaCache,
+		resizer:        options.Resizer,
+		publisher:      options.Publisher,
+		markdown:       options.Markdown,
+		bruteForce:     options.BruteForce,
+		codeAttempts:   options.CodeAttempts,
+		resendCooldown: options.ResendCooldown,
+		avatars:        options.Avatars,
+		avatarCache:    options.AvatarCache,
 	}
 }
 
@@ -68,3 +143,203 @@ func validateGetAllParams(c *gin.Context) (*models.GetAllParams, error) {
 		Search: c.Query("search"),
 	}, nil
 }
+
+func validateCursorParams(c *gin.Context) (*models.GetAllCommentsParams, error) {
+	var (
+		limit int = 10
+		err   error
+	)
+
+	if c.Query("limit") != "" {
+		limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || limit <= 0 || limit > 100 {
+			return nil, errors.New("limit must be between 1 and 100")
+		}
+	}
+
+	return &models.GetAllCommentsParams{
+		Limit:  int32(limit),
+		Cursor: c.Query("cursor"),
+	}, nil
+}
+
+func validateGetAllPostsParams(c *gin.Context) (*models.GetAllPostsParams, error) {
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		userID     int
+		categoryID int
+		sortBy     = "date"
+		order      = "desc"
+		postStatus = postStatusPublished
+	)
+
+	if c.Query("user_id") != "" {
+		userID, err = strconv.Atoi(c.Query("user_id"))
+		if err != nil || userID <= 0 {
+			return nil, errors.New("user_id must be a positive integer")
+		}
+	}
+
+	if c.Query("category_id") != "" {
+		categoryID, err = strconv.Atoi(c.Query("category_id"))
+		if err != nil || categoryID <= 0 {
+			return nil, errors.New("category_id must be a positive integer")
+		}
+	}
+
+	// sort_by_date was replaced by sort_by and order, old clients still send it
+	if c.Query("sort_by_date") != "" {
+		order = c.Query("sort_by_date")
+		if order != "asc" && order != "desc" {
+			return nil, errors.New("sort_by_date must be one of: asc, desc; use sort_by=date and order instead")
+		}
+	}
+
+	if c.Query("sort_by") != "" {
+		sortBy = c.Query("sort_by")
+		if sortBy != "date" && sortBy != "views" && sortBy != "likes" {
+			return nil, errors.New("sort_by must be one of: date, views, likes")
+		}
+	}
+
+	if c.Query("order") != "" {
+		order = c.Query("order")
+		if order != "asc" && order != "desc" {
+			return nil, errors.New("order must be one of: asc, desc")
+		}
+	}
+
+	if c.Query("status") != "" {
+		postStatus = c.Query("status")
+		if !isValidPostStatus(postStatus) {
+			return nil, errors.New("status must be one of: draft, published, scheduled, archived")
+		}
+	}
+
+	tag := ""
+	if c.Query("tag") != "" {
+		tag = slug.Make(c.Query("tag"))
+		if tag == "" {
+			return nil, ErrInvalidTag
+		}
+	}
+
+	fromDate, toDate := c.Query("from_date"), c.Query("to_date")
+	if err := validateDateRange(fromDate, toDate); err != nil {
+		return nil, err
+	}
+
+	return &models.GetAllPostsParams{
+		Limit:      params.Limit,
+		Page:       params.Page,
+		Search:     params.Search,
+		UserID:     int64(userID),
+		CategoryID: int64(categoryID),
+		FromDate:   fromDate,
+		ToDate:     toDate,
+		SortBy:     sortBy,
+		Order:      order,
+		Status:     postStatus,
+		Tag:        tag,
+	}, nil
+}
+
+// validatePassword enforces the password policy. The length limits match
+// the ones on registration.
+func validatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < 6 || length > 16 {
+		return ErrWeakPassword
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrWeakPassword
+	}
+
+	return nil
+}
+
+// normalizeTags slugifies and deduplicates tags keeping their order.
+func normalizeTags(tags []string, limit int) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		s := slug.Make(tag)
+		if s == "" || utf8.RuneCountInString(s) > 50 {
+			return nil, ErrInvalidTag
+		}
+
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+
+	if len(result) > limit {
+		return nil, fmt.Errorf("a post can have at most %d tags", limit)
+	}
+
+	return result, nil
+}
+
+// setRetryAfter tells the client how many seconds to wait, rounding up.
+func setRetryAfter(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+// statusReason returns the reason of the ErrorInfo detail of a gRPC error,
+// or an empty string when there is none.
+func statusReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || status.Code(err) == codes.NotFound
+}
+
+func validateDateRange(from, to string) error {
+	var fromTime, toTime time.Time
+
+	if from != "" {
+		t, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return errors.New("from_date must be in YYYY-MM-DD format")
+		}
+		fromTime = t
+	}
+
+	if to != "" {
+		t, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return errors.New("to_date must be in YYYY-MM-DD format")
+		}
+		toTime = t
+	}
+
+	if from != "" && to != "" && toTime.Before(fromTime) {
+		return errors.New("to_date must not be before from_date")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	reactionLike    = "like"
+	reactionDislike = "dislike"
+)
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/like [post]
+// @Summary Like a post
+// @Description Like a post. Replaces a dislike made by the same user
+// @Tags like
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) LikePost(c *gin.Context) {
+	h.reactToPost(c, reactionLike)
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/dislike [post]
+// @Summary Dislike a post
+// @Description Dislike a post. Replaces a like made by the same user
+// @Tags like
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DislikePost(c *gin.Context) {
+	h.reactToPost(c, reactionDislike)
+}
+
+func (h *handlerV1) reactToPost(c *gin.Context, reaction string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.getVisiblePost(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = h.grpcClient.LikeService().CreateOrUpdate(context.Background(), &pbp.Like{
+		PostId:   int64(id),
+		UserId:   payload.UserID,
+		Reaction: reaction,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	post, err := h.getPost(c, int64(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/reaction [delete]
+// @Summary Remove reaction from a post
+// @Description Remove the current user's like or dislike from a post
+// @Tags like
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DeletePostReaction(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	_, err = h.grpcClient.LikeService().Delete(context.Background(), &pbp.GetLikeRequest{
+		PostId: int64(id),
+		UserId: payload.UserID,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	post, err := h.getPost(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
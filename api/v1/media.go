@@ -0,0 +1,280 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/media"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrFileTooLarge     = errors.New("file is too large")
+	ErrInvalidMediaSize = errors.New("width and height must be one of the preset sizes")
+	mediaIDRegexp       = regexp.MustCompile(`^[0-9a-f]{32}\.(jpg|png|webp|gif)$`)
+)
+
+// @Security ApiKeyAuth
+// @Router /media [post]
+// @Summary Upload an image
+// @Description Upload an image for posts or profiles. Metadata such as EXIF is removed
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image (jpeg, png, webp or gif)"
+// @Success 201 {object} models.Media
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UploadMedia(c *gin.Context) {
+	maxSize := h.cfg.MediaMaxSize
+
+	// leave some room for the multipart envelope
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrFileTooLarge))
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrFileTooLarge))
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if int64(len(data)) > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrFileTooLarge))
+		return
+	}
+
+	info, err := media.DetectImage(data)
+	if err != nil {
+		if errors.Is(err, media.ErrUnsupportedType) {
+			c.JSON(http.StatusUnsupportedMediaType, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	data, err = media.StripMetadata(info.ContentType, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// an EXIF orientation may have turned the image
+	info, err = media.DetectImage(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := newMediaID(info.Extension)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.storage.Put(context.Background(), id, bytes.NewReader(data), int64(len(data)), info.ContentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.Media{
+		ID:          id,
+		URL:         h.mediaURL(id),
+		ContentType: info.ContentType,
+		Size:        int64(len(data)),
+		Width:       info.Width,
+		Height:      info.Height,
+	})
+}
+
+// @Router /media/{id} [get]
+// @Summary Get an image
+// @Description Get an uploaded image, optionally resized and converted.
+// @Description Width and height must be one of the preset sizes.
+// @Tags media
+// @Produce image/jpeg,image/png,image/webp,image/gif
+// @Param id path string true "ID"
+// @Param filter query models.GetMediaParams false "Variant"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetMedia(c *gin.Context) {
+	id := c.Param("id")
+	if !mediaIDRegexp.MatchString(id) {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	variant, err := h.parseMediaVariant(c, id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if variant == nil {
+		h.serveOriginalMedia(c, id)
+		return
+	}
+
+	cacheKey := strings.TrimSuffix(id, filepath.Ext(id)) + "-" + variant.Key()
+	if data, ok := h.mediaCache.Get(cacheKey); ok {
+		setImmutableCacheHeaders(c)
+		c.Data(http.StatusOK, variant.ContentType(), data)
+		return
+	}
+
+	obj, err := h.storage.Get(context.Background(), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer obj.Body.Close()
+
+	original, err := io.ReadAll(obj.Body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	data, err := h.resizer.Resize(original, *variant)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.mediaCache.Put(cacheKey, data)
+	if err != nil {
+		log.Printf("failed to cache media variant %s: %v", cacheKey, err)
+	}
+
+	setImmutableCacheHeaders(c)
+	c.Data(http.StatusOK, variant.ContentType(), data)
+}
+
+func (h *handlerV1) serveOriginalMedia(c *gin.Context, id string) {
+	obj, err := h.storage.Get(context.Background(), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer obj.Body.Close()
+
+	setImmutableCacheHeaders(c)
+	c.DataFromReader(http.StatusOK, obj.Size, obj.ContentType, obj.Body, nil)
+}
+
+// parseMediaVariant returns nil when the original image is requested.
+func (h *handlerV1) parseMediaVariant(c *gin.Context, id string) (*media.Variant, error) {
+	if c.Query("width") == "" && c.Query("height") == "" && c.Query("fit") == "" && c.Query("format") == "" {
+		return nil, nil
+	}
+
+	variant := media.Variant{
+		Fit:    media.FitCover,
+		Format: media.FormatOf(mime.TypeByExtension(filepath.Ext(id))),
+	}
+
+	var err error
+	if c.Query("width") != "" {
+		variant.Width, err = strconv.Atoi(c.Query("width"))
+		if err != nil || !h.isPresetSize(variant.Width) {
+			return nil, ErrInvalidMediaSize
+		}
+	}
+
+	if c.Query("height") != "" {
+		variant.Height, err = strconv.Atoi(c.Query("height"))
+		if err != nil || !h.isPresetSize(variant.Height) {
+			return nil, ErrInvalidMediaSize
+		}
+	}
+
+	if c.Query("fit") != "" {
+		variant.Fit = c.Query("fit")
+		if variant.Fit != media.FitCover && variant.Fit != media.FitContain && variant.Fit != media.FitFill {
+			return nil, errors.New("fit must be one of: cover, contain, fill")
+		}
+	}
+
+	if c.Query("format") != "" {
+		variant.Format = c.Query("format")
+		if variant.Format != media.FormatJPEG && variant.Format != media.FormatPNG && variant.Format != media.FormatWebP {
+			return nil, errors.New("format must be one of: jpeg, png, webp")
+		}
+	}
+	variant.Format = media.OutputFormat(variant.Format)
+
+	return &variant, nil
+}
+
+func (h *handlerV1) isPresetSize(size int) bool {
+	for _, preset := range h.cfg.MediaPresetSizes {
+		if size == preset {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setImmutableCacheHeaders is safe because media ids are never reused and
+// a variant is fully determined by its query parameters.
+func setImmutableCacheHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+}
+
+func (h *handlerV1) mediaURL(id string) string {
+	return strings.TrimRight(h.cfg.MediaPublicURL, "/") + "/v1/media/" + id
+}
+
+func newMediaID(ext string) (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b) + ext, nil
+}
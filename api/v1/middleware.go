@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "Authorization"
+	authorizationPayloadKey = "authorization_payload"
+
+	userTypeSuperadmin = "superadmin"
+	userTypeUser       = "user"
+)
+
+// AuthMiddleware rejects requests without a valid access token and stores
+// the token payload in the context for the handlers.
+func (h *handlerV1) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, err := h.verifyAccessToken(c)
+		if err != nil || payload == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+			return
+		}
+
+		c.Set(authorizationPayloadKey, payload)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware lets anonymous requests through, but still
+// resolves the payload when an access token is sent.
+func (h *handlerV1) OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, err := h.verifyAccessToken(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+			return
+		}
+
+		if payload != nil {
+			c.Set(authorizationPayloadKey, payload)
+		}
+		c.Next()
+	}
+}
+
+// SuperadminMiddleware must run after AuthMiddleware and lets through
+// superadmins only.
+func (h *handlerV1) SuperadminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, ok := getAuthPayload(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+			return
+		}
+
+		if payload.UserType != userTypeSuperadmin {
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrForbidden))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *handlerV1) verifyAccessToken(c *gin.Context) (*models.AuthPayload, error) {
+	accessToken := strings.TrimSpace(c.GetHeader(authorizationHeaderKey))
+	accessToken = strings.TrimSpace(strings.TrimPrefix(accessToken, "Bearer "))
+	if accessToken == "" {
+		return nil, nil
+	}
+
+	payload, err := h.grpcClient.AuthService().VerifyToken(context.Background(), &pbu.VerifyTokenRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthPayload{
+		ID:        payload.Id,
+		UserID:    payload.UserId,
+		Email:     payload.Email,
+		UserType:  payload.UserType,
+		IssuedAt:  payload.IssuedAt,
+		ExpiredAt: payload.ExpiredAt,
+	}, nil
+}
+
+func getAuthPayload(c *gin.Context) (*models.AuthPayload, bool) {
+	value, exists := c.Get(authorizationPayloadKey)
+	if !exists {
+		return nil, false
+	}
+
+	payload, ok := value.(*models.AuthPayload)
+	return payload, ok
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbn "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/notification_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// @Security ApiKeyAuth
+// @Router /users/me/phone/code [post]
+// @Summary Send phone verification code
+// @Description Send a verification code by SMS to the phone number of the authenticated user.
+// @Description A new code replaces the previous one and can be requested once per cooldown
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) SendPhoneCode(c *gin.Context) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	key := phoneCodeKey(payload.UserID)
+	if wait := h.resendCooldown.Remaining(key); wait > 0 {
+		setRetryAfter(c, wait)
+		c.JSON(http.StatusTooManyRequests, errorResponse(ErrResendTooSoon))
+		return
+	}
+
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: payload.UserID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.PhoneNumber == "" {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrNoPhone))
+		return
+	}
+
+	if user.PhoneVerified {
+		c.JSON(http.StatusConflict, errorResponse(ErrPhoneVerified))
+		return
+	}
+
+	code, err := h.grpcClient.AuthService().CreatePhoneCode(context.Background(), &pbu.CreatePhoneCodeRequest{
+		UserId:      user.Id,
+		PhoneNumber: user.PhoneNumber,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = h.grpcClient.NotificationService().SendSms(context.Background(), &pbn.SendSmsRequest{
+		To:   user.PhoneNumber,
+		Type: "phone_verification",
+		Body: map[string]string{
+			"code":       code.Code,
+			"expires_at": code.ExpiresAt,
+		},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	h.codeSent(key)
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "Verification code has been sent",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /users/me/phone/verify [post]
+// @Summary Verify phone number
+// @Description Verify the phone number of the authenticated user with the code sent to it.
+// @Description After too many incorrect attempts the code stops working and a new one has to be requested
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body models.VerifyPhoneRequest true "Code"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) VerifyPhone(c *gin.Context) {
+	var (
+		req models.VerifyPhoneRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	key := phoneCodeKey(payload.UserID)
+	if wait := h.codeAttempts.Attempt(key); wait > 0 {
+		setRetryAfter(c, wait)
+		c.JSON(http.StatusTooManyRequests, errorResponse(ErrCodeAttempts))
+		return
+	}
+
+	_, err = h.grpcClient.AuthService().VerifyPhoneCode(context.Background(), &pbu.VerifyPhoneCodeRequest{
+		UserId: payload.UserID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.AlreadyExists:
+			c.JSON(http.StatusConflict, errorResponse(ErrPhoneVerified))
+		case codes.NotFound:
+			// no code was sent, or the phone number changed since
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		default:
+			h.codeError(c, key, err)
+		}
+		return
+	}
+	h.codeAttempts.Reset(key)
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "phone number has been verified",
+	})
+}
+
+func phoneCodeKey(userID int64) string {
+	return fmt.Sprintf("phone:%d", userID)
+}
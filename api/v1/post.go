@@ -2,20 +2,52 @@ package v1
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
 	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+const (
+	postStatusDraft     = "draft"
+	postStatusPublished = "published"
+	postStatusScheduled = "scheduled"
+	postStatusArchived  = "archived"
+
+	// excerptLength is the maximum number of characters in a post excerpt.
+	excerptLength = 200
+)
+
+func isValidPostStatus(value string) bool {
+	switch value {
+	case postStatusDraft, postStatusPublished, postStatusScheduled, postStatusArchived:
+		return true
+	}
+
+	return false
+}
+
+// @Security ApiKeyAuth
 // @Router /posts [post]
 // @Summary Create a post
-// @Description Create a post
+// @Description Create a post. It is published immediately unless status is draft or scheduled.
+// @Description Deleted users have to restore their account first
 // @Tags post
 // @Accept json
 // @Produce json
 // @Param post body models.CreatePostRequest true "post"
+// @Param expand query []string false "Embed related resources" collectionFormat(csv) Enums(author, category)
 // @Success 201 {object} models.Post
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) CreatePost(c *gin.Context) {
 	var (
@@ -28,31 +60,612 @@ func (h *handlerV1) CreatePost(c *gin.Context) {
 		return
 	}
 
+	expand, err := parseExpandParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	// the posts of deleted users are hidden until they restore the account,
+	// new posts would not be
+	author, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: payload.UserID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if author.DeletedAt != "" {
+		c.JSON(http.StatusForbidden, errorResponse(ErrAccountDeleted))
+		return
+	}
+
+	tags, err := normalizeTags(req.Tags, h.cfg.MaxPostTags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var (
+		postStatus = req.Status
+		publishAt  time.Time
+	)
+	switch postStatus {
+	case "", postStatusPublished:
+		postStatus = postStatusPublished
+		publishAt = time.Now().UTC()
+	case postStatusScheduled:
+		publishAt, err = parsePublishAt(req.PublishAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
 	resp, err := h.grpcClient.PostService().Create(context.Background(), &pbp.Post{
 		Title:       req.Title,
 		Description: req.Description,
 		ImageUrl:    req.ImageUrl,
-		UserId:      1,
+		UserId:      payload.UserID,
 		CategoryId:  req.CategoryID,
+		Status:      postStatus,
+		PublishAt:   formatPublishAt(publishAt),
+		Tags:        tags,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	post := parsePostModel(resp)
+	if resp.Status == postStatusScheduled && h.publisher != nil {
+		h.publisher.Schedule(resp.Id, publishAt)
+	}
+
+	post := h.parsePostModel(resp)
+	h.expandPosts(expand, &post)
+
 	c.JSON(http.StatusCreated, post)
 }
 
-func parsePostModel(post *pbp.Post) models.Post {
+// @Router /posts/{id} [get]
+// @Summary Get post by id
+// @Description Get post by id
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param expand query []string false "Embed related resources" collectionFormat(csv) Enums(author, category)
+// @Param render query string false "Also return the body rendered to sanitized HTML with a table of contents" Enums(markdown, html) default(markdown)
+// @Success 200 {object} models.Post
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetPost(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	expand, err := parseExpandParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	render := c.DefaultQuery("render", "markdown")
+	if render != "markdown" && render != "html" {
+		c.JSON(http.StatusBadRequest, errorResponse(errors.New("render must be one of: markdown, html")))
+		return
+	}
+
+	post, err := h.getPost(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if render == "html" {
+		err = h.renderPost(post)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	h.expandPosts(expand, post)
+
+	if h.viewCounter != nil {
+		h.viewCounter.Hit(post.ID, viewerKey(c))
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// viewerKey identifies the reader for view deduplication: the user id when
+// authenticated, otherwise a hash of the client ip and user agent.
+func viewerKey(c *gin.Context) string {
+	if payload, ok := getAuthPayload(c); ok {
+		return fmt.Sprintf("user:%d", payload.UserID)
+	}
+
+	sum := sha1.Sum([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+	return "anon:" + hex.EncodeToString(sum[:])
+}
+
+func (h *handlerV1) getPost(c *gin.Context, id int64) (*models.Post, error) {
+	resp, err := h.getVisiblePost(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	post := h.parsePostModel(resp)
+	err = h.fillPostsLikeInfo(context.Background(), c, &post)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.fillPostsBookmarked(context.Background(), c, &post)
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// getVisiblePost hides posts that are not published from everyone but
+// their author, as if they did not exist.
+func (h *handlerV1) getVisiblePost(c *gin.Context, id int64) (*pbp.Post, error) {
+	post, err := h.grpcClient.PostService().Get(context.Background(), &pbp.GetPostRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if !canViewPost(c, post) {
+		return nil, ErrNotFound
+	}
+
+	return post, nil
+}
+
+func canViewPost(c *gin.Context, post *pbp.Post) bool {
+	if post.Status == "" || post.Status == postStatusPublished {
+		return true
+	}
+
+	payload, ok := getAuthPayload(c)
+	return ok && payload.UserID == post.UserId
+}
+
+// fillPostsLikeInfo loads reaction counters for the given posts in one call
+// and, for authenticated requests, the caller's own reaction.
+func (h *handlerV1) fillPostsLikeInfo(ctx context.Context, c *gin.Context, posts ...*models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	req := pbp.GetLikesInfoRequest{
+		PostIds: make([]int64, 0, len(posts)),
+	}
+	for _, post := range posts {
+		req.PostIds = append(req.PostIds, post.ID)
+	}
+	if payload, ok := getAuthPayload(c); ok {
+		req.UserId = payload.UserID
+	}
+
+	resp, err := h.grpcClient.LikeService().GetLikesInfo(ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	infos := make(map[int64]*pbp.LikesInfo, len(resp.Infos))
+	for _, info := range resp.Infos {
+		infos[info.PostId] = info
+	}
+
+	for _, post := range posts {
+		info, ok := infos[post.ID]
+		if !ok {
+			continue
+		}
+		post.LikeInfo = models.PostLikeInfo{
+			LikesCount:    info.LikesCount,
+			DislikesCount: info.DislikesCount,
+		}
+		post.MyReaction = info.MyReaction
+	}
+
+	return nil
+}
+
+// renderPost fills the HTML body and table of contents. Results are cached
+// per revision, so an edit (which bumps updated_at) renders the post again.
+func (h *handlerV1) renderPost(post *models.Post) error {
+	result, err := h.markdown.Render(postRevision(post.ID, post.CreatedAt, post.UpdatedAt), post.Description)
+	if err != nil {
+		return err
+	}
+
+	post.DescriptionHTML = result.HTML
+	post.TOC = make([]models.TOCEntry, 0, len(result.TOC))
+	for _, entry := range result.TOC {
+		post.TOC = append(post.TOC, models.TOCEntry{
+			Level: entry.Level,
+			ID:    entry.ID,
+			Title: entry.Title,
+		})
+	}
+
+	return nil
+}
+
+// postRevision identifies the body of a post for the markdown caches.
+func postRevision(id int64, createdAt, updatedAt string) string {
+	revision := updatedAt
+	if revision == "" {
+		revision = createdAt
+	}
+
+	return fmt.Sprintf("%d:%s", id, revision)
+}
+
+// parsePostModel maps the post with the stats of its body, which are cached
+// per revision because lists map every post they return.
+func (h *handlerV1) parsePostModel(post *pbp.Post) models.Post {
+	stats := h.markdown.Analyze(postRevision(post.Id, post.CreatedAt, post.UpdatedAt), post.Description, excerptLength)
+
 	return models.Post{
-		ID:          post.Id,
-		Title:       post.Title,
-		Description: post.Description,
-		ImageUrl:    post.ImageUrl,
-		UserID:      post.UserId,
-		CategoryID:  post.CategoryId,
-		CreatedAt:   post.CreatedAt,
-		ViewsCount:  post.ViewsCount,
+		ID:                 post.Id,
+		Title:              post.Title,
+		Description:        post.Description,
+		Excerpt:            stats.Excerpt,
+		WordCount:          stats.WordCount,
+		ReadingTimeMinutes: stats.ReadingTimeMinutes,
+		ImageUrl:           post.ImageUrl,
+		UserID:             post.UserId,
+		CategoryID:         post.CategoryId,
+		CreatedAt:          post.CreatedAt,
+		UpdatedAt:          post.UpdatedAt,
+		ViewsCount:         post.ViewsCount,
+		Status:             post.Status,
+		PublishAt:          post.PublishAt,
+		Tags:               post.Tags,
+	}
+}
+
+// @Router /posts [get]
+// @Summary Get all posts
+// @Description Get all posts filtered by author, category and date range, sorted by date, views or likes.
+// @Description Only published posts are listed, except for the caller's own posts.
+// @Description Posts carry an excerpt instead of the full body, which is only returned by GET /posts/{id}.
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param filter query models.GetAllPostsParams false "Filter"
+// @Param expand query []string false "Embed related resources" collectionFormat(csv) Enums(author, category)
+// @Success 200 {object} models.GetAllPostsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAllPosts(c *gin.Context) {
+	req, err := validateGetAllPostsParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	h.listPosts(c, req)
+}
+
+func (h *handlerV1) listPosts(c *gin.Context, req *models.GetAllPostsParams) {
+	if req.Status != postStatusPublished {
+		payload, ok := getAuthPayload(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+			return
+		}
+
+		if req.UserID == 0 {
+			req.UserID = payload.UserID
+		}
+		if req.UserID != payload.UserID {
+			c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+			return
+		}
+	}
+
+	expand, err := parseExpandParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.PostService().GetAll(context.Background(), &pbp.GetAllPostsRequest{
+		Limit:      req.Limit,
+		Page:       req.Page,
+		Search:     req.Search,
+		UserId:     req.UserID,
+		CategoryId: req.CategoryID,
+		FromDate:   req.FromDate,
+		ToDate:     req.ToDate,
+		SortBy:     req.SortBy,
+		Order:      req.Order,
+		Status:     req.Status,
+		Tag:        req.Tag,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := h.getPostsResponse(result)
+	err = h.fillPostsLikeInfo(context.Background(), c, response.Posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.fillPostsBookmarked(context.Background(), c, response.Posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	h.expandPosts(expand, response.Posts...)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *handlerV1) getPostsResponse(data *pbp.GetAllPostsResponse) *models.GetAllPostsResponse {
+	response := models.GetAllPostsResponse{
+		Posts: make([]*models.Post, 0),
+		Count: data.Count,
+	}
+
+	for _, post := range data.Posts {
+		p := h.parsePostModel(post)
+		p.Description = ""
+		response.Posts = append(response.Posts, &p)
+	}
+
+	return &response
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id} [put]
+// @Summary Update a post
+// @Description Update a post. Only its author can edit it
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param post body models.UpdatePostRequest true "post"
+// @Success 200 {object} models.Post
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UpdatePost(c *gin.Context) {
+	var (
+		req models.UpdatePostRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
+
+	tags, err := normalizeTags(req.Tags, h.cfg.MaxPostTags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	post, err := h.getVisiblePost(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if post.UserId != payload.UserID {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+		return
+	}
+
+	post.Title = req.Title
+	post.Description = req.Description
+	post.ImageUrl = req.ImageUrl
+	post.CategoryId = req.CategoryID
+	post.Tags = tags
+
+	_, err = h.grpcClient.PostService().Update(context.Background(), post)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := h.getPost(c, post.Id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/publish [post]
+// @Summary Publish a post
+// @Description Publish a draft, scheduled or archived post right away
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) PublishPost(c *gin.Context) {
+	h.changePostStatus(c, postStatusPublished, time.Now().UTC())
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/unpublish [post]
+// @Summary Unpublish a post
+// @Description Move a post back to drafts
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UnpublishPost(c *gin.Context) {
+	h.changePostStatus(c, postStatusDraft, time.Time{})
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/archive [post]
+// @Summary Archive a post
+// @Description Archive a post so that only its author can see it
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.Post
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ArchivePost(c *gin.Context) {
+	h.changePostStatus(c, postStatusArchived, time.Time{})
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/schedule [post]
+// @Summary Schedule a post
+// @Description Schedule a post to be published at publish_at
+// @Tags post
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param data body models.SchedulePostRequest true "Data"
+// @Success 200 {object} models.Post
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) SchedulePost(c *gin.Context) {
+	var (
+		req models.SchedulePostRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	publishAt, err := parsePublishAt(req.PublishAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	h.changePostStatus(c, postStatusScheduled, publishAt)
+}
+
+// changePostStatus sets the status of the post; publishAt is zero for
+// statuses without a publish time.
+func (h *handlerV1) changePostStatus(c *gin.Context, postStatus string, publishAt time.Time) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	post, err := h.getVisiblePost(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if post.UserId != payload.UserID {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+		return
+	}
+
+	_, err = h.grpcClient.PostService().UpdateStatus(context.Background(), &pbp.UpdatePostStatusRequest{
+		Id:        post.Id,
+		Status:    postStatus,
+		PublishAt: formatPublishAt(publishAt),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if postStatus == postStatusScheduled && h.publisher != nil {
+		h.publisher.Schedule(post.Id, publishAt)
+	}
+
+	result, err := h.getPost(c, post.Id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func parsePublishAt(value string) (time.Time, error) {
+	publishAt, err := time.Parse(time.RFC3339, value)
+	if err != nil || !publishAt.After(time.Now()) {
+		return time.Time{}, ErrInvalidPublishAt
+	}
+
+	return publishAt.UTC(), nil
+}
+
+func formatPublishAt(publishAt time.Time) string {
+	if publishAt.IsZero() {
+		return ""
+	}
+
+	return publishAt.UTC().Format(time.RFC3339)
 }
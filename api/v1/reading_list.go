@@ -0,0 +1,705 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultReadingListName is the name of the list every user gets on first
+// use; bookmarking a post adds it there.
+const defaultReadingListName = "Saved"
+
+// @Security ApiKeyAuth
+// @Router /reading-lists [post]
+// @Summary Create a reading list
+// @Description Create a named reading list for the current user
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param list body models.CreateReadingListRequest true "Reading list"
+// @Success 201 {object} models.ReadingList
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) CreateReadingList(c *gin.Context) {
+	var (
+		req models.CreateReadingListRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	resp, err := h.grpcClient.ReadingListService().Create(context.Background(), &pbp.ReadingList{
+		UserId:   payload.UserID,
+		Name:     req.Name,
+		IsPublic: req.IsPublic,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, parseReadingListModel(resp))
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists [get]
+// @Summary Get reading lists
+// @Description Get reading lists of a user. Without user_id the current user's lists are returned,
+// @Description including the default "Saved" list. Other users' lists are listed only when public.
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param filter query models.GetAllReadingListsParams false "Filter"
+// @Success 200 {object} models.GetAllReadingListsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAllReadingLists(c *gin.Context) {
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var userID int
+	if c.Query("user_id") != "" {
+		userID, err = strconv.Atoi(c.Query("user_id"))
+		if err != nil || userID <= 0 {
+			c.JSON(http.StatusBadRequest, errorResponse(errors.New("user_id must be a positive integer")))
+			return
+		}
+	}
+
+	payload, authenticated := getAuthPayload(c)
+	if userID == 0 {
+		if !authenticated {
+			c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+			return
+		}
+		userID = int(payload.UserID)
+	}
+	own := authenticated && payload.UserID == int64(userID)
+
+	if own {
+		_, err = h.grpcClient.ReadingListService().GetOrCreateDefault(context.Background(), &pbp.GetDefaultReadingListRequest{
+			UserId: payload.UserID,
+			Name:   defaultReadingListName,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	result, err := h.grpcClient.ReadingListService().GetAll(context.Background(), &pbp.GetAllReadingListsRequest{
+		UserId:     int64(userID),
+		PublicOnly: !own,
+		Limit:      params.Limit,
+		Page:       params.Page,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := models.GetAllReadingListsResponse{
+		Lists: make([]*models.ReadingList, 0),
+		Count: result.Count,
+	}
+
+	for _, list := range result.Lists {
+		l := parseReadingListModel(list)
+		response.Lists = append(response.Lists, &l)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Router /reading-lists/{id} [get]
+// @Summary Get a reading list
+// @Description Get a reading list. Private lists are visible to their owner only
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.ReadingList
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetReadingList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, err := h.getVisibleReadingList(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, parseReadingListModel(list))
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists/{id} [put]
+// @Summary Update a reading list
+// @Description Rename a reading list or make it public or private. Only fields present in the body are changed.
+// @Description The default list cannot be renamed
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param list body models.UpdateReadingListRequest true "Reading list"
+// @Success 200 {object} models.ReadingList
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UpdateReadingList(c *gin.Context) {
+	var (
+		req models.UpdateReadingListRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getOwnReadingList(c, int64(id))
+	if !ok {
+		return
+	}
+
+	if req.Name != nil {
+		if list.IsDefault && *req.Name != list.Name {
+			c.JSON(http.StatusBadRequest, errorResponse(ErrDefaultList))
+			return
+		}
+		list.Name = *req.Name
+	}
+	if req.IsPublic != nil {
+		list.IsPublic = *req.IsPublic
+	}
+
+	resp, err := h.grpcClient.ReadingListService().Update(context.Background(), list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, parseReadingListModel(resp))
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists/{id} [delete]
+// @Summary Delete a reading list
+// @Description Delete a reading list. The default list cannot be deleted
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DeleteReadingList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getOwnReadingList(c, int64(id))
+	if !ok {
+		return
+	}
+
+	if list.IsDefault {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrDefaultList))
+		return
+	}
+
+	_, err = h.grpcClient.ReadingListService().Delete(context.Background(), &pbp.GetReadingListRequest{Id: list.Id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// @Router /reading-lists/{id}/posts [get]
+// @Summary Get reading list posts
+// @Description Get posts of a reading list in the owner's order
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllPostsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetReadingListPosts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, err := h.getVisibleReadingList(c, int64(id))
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	req := pbp.GetReadingListPostsRequest{
+		ListId: list.Id,
+		Limit:  params.Limit,
+		Page:   params.Page,
+	}
+	if payload, ok := getAuthPayload(c); ok {
+		req.ViewerId = payload.UserID
+	}
+
+	result, err := h.grpcClient.ReadingListService().GetPosts(context.Background(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// posts saved while published may have been unpublished since. The
+	// post service already leaves them out for the viewer, this only guards
+	// the response.
+	posts := &pbp.GetAllPostsResponse{Count: result.Count}
+	for _, post := range result.Posts {
+		if canViewPost(c, post) {
+			posts.Posts = append(posts.Posts, post)
+		}
+	}
+
+	response := h.getPostsResponse(posts)
+	err = h.fillPostsLikeInfo(context.Background(), c, response.Posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = h.fillPostsBookmarked(context.Background(), c, response.Posts...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists/{id}/posts [post]
+// @Summary Add a post to a reading list
+// @Description Add a post to the end of a reading list
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param post body models.AddReadingListPostRequest true "Post"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) AddReadingListPost(c *gin.Context) {
+	var (
+		req models.AddReadingListPostRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getOwnReadingList(c, int64(id))
+	if !ok {
+		return
+	}
+
+	h.addReadingListPost(c, list.Id, req.PostID)
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists/{id}/posts/{post_id} [delete]
+// @Summary Remove a post from a reading list
+// @Description Remove a post from a reading list
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param post_id path int true "Post ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) RemoveReadingListPost(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	postID, err := strconv.Atoi(c.Param("post_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getOwnReadingList(c, int64(id))
+	if !ok {
+		return
+	}
+
+	h.removeReadingListPost(c, list.Id, int64(postID))
+}
+
+// @Security ApiKeyAuth
+// @Router /reading-lists/{id}/posts/order [put]
+// @Summary Reorder a reading list
+// @Description Set the order of posts in a reading list. post_ids must contain every post of the list
+// @Description the owner can see; posts unpublished by their authors keep their order at the end
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "ID"
+// @Param order body models.ReorderReadingListRequest true "Order"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ReorderReadingList(c *gin.Context) {
+	var (
+		req models.ReorderReadingListRequest
+	)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getOwnReadingList(c, int64(id))
+	if !ok {
+		return
+	}
+
+	seen := make(map[int64]bool, len(req.PostIDs))
+	for _, postID := range req.PostIDs {
+		if seen[postID] {
+			c.JSON(http.StatusBadRequest, errorResponse(errors.New("post_ids must not contain duplicates")))
+			return
+		}
+		seen[postID] = true
+	}
+
+	// posts unpublished since they were saved are not listed to the owner,
+	// so they cannot be part of the order
+	visible, err := h.grpcClient.ReadingListService().GetPosts(context.Background(), &pbp.GetReadingListPostsRequest{
+		ListId:   list.Id,
+		Limit:    1,
+		Page:     1,
+		ViewerId: list.UserId,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if int32(len(req.PostIDs)) != visible.Count {
+		c.JSON(http.StatusBadRequest, errorResponse(errors.New("post_ids must contain every post of the list")))
+		return
+	}
+
+	_, err = h.grpcClient.ReadingListService().Reorder(context.Background(), &pbp.ReorderReadingListRequest{
+		ListId:   list.Id,
+		PostIds:  req.PostIDs,
+		ViewerId: list.UserId,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			// the list changed after it was read above
+			c.JSON(http.StatusBadRequest, errorResponse(errors.New("post_ids must contain every post of the list")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/bookmark [post]
+// @Summary Bookmark a post
+// @Description Save a post to the default "Saved" reading list
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) BookmarkPost(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getDefaultReadingList(c)
+	if !ok {
+		return
+	}
+
+	h.addReadingListPost(c, list.Id, int64(postID))
+}
+
+// @Security ApiKeyAuth
+// @Router /posts/{id}/bookmark [delete]
+// @Summary Remove a bookmark
+// @Description Remove a post from the default "Saved" reading list
+// @Tags reading-list
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} models.ResponseOK
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UnbookmarkPost(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	list, ok := h.getDefaultReadingList(c)
+	if !ok {
+		return
+	}
+
+	h.removeReadingListPost(c, list.Id, int64(postID))
+}
+
+func (h *handlerV1) addReadingListPost(c *gin.Context, listID, postID int64) {
+	_, err := h.getVisiblePost(c, postID)
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_, err = h.grpcClient.ReadingListService().AddPost(context.Background(), &pbp.ReadingListPostRequest{
+		ListId: listID,
+		PostId: postID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+func (h *handlerV1) removeReadingListPost(c *gin.Context, listID, postID int64) {
+	_, err := h.grpcClient.ReadingListService().RemovePost(context.Background(), &pbp.ReadingListPostRequest{
+		ListId: listID,
+		PostId: postID,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ResponseOK{
+		Message: "success",
+	})
+}
+
+// getVisibleReadingList hides private lists from everyone but their owner,
+// as if they did not exist.
+func (h *handlerV1) getVisibleReadingList(c *gin.Context, id int64) (*pbp.ReadingList, error) {
+	list, err := h.grpcClient.ReadingListService().Get(context.Background(), &pbp.GetReadingListRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if list.IsPublic {
+		return list, nil
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok || payload.UserID != list.UserId {
+		return nil, ErrNotFound
+	}
+
+	return list, nil
+}
+
+// getOwnReadingList writes the error response itself and reports whether
+// the caller may modify the list.
+func (h *handlerV1) getOwnReadingList(c *gin.Context, id int64) (*pbp.ReadingList, bool) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return nil, false
+	}
+
+	list, err := h.getVisibleReadingList(c, id)
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	if list.UserId != payload.UserID {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+		return nil, false
+	}
+
+	return list, true
+}
+
+func (h *handlerV1) getDefaultReadingList(c *gin.Context) (*pbp.ReadingList, bool) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return nil, false
+	}
+
+	list, err := h.grpcClient.ReadingListService().GetOrCreateDefault(context.Background(), &pbp.GetDefaultReadingListRequest{
+		UserId: payload.UserID,
+		Name:   defaultReadingListName,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	return list, true
+}
+
+// fillPostsBookmarked marks the posts the caller saved to any of their
+// reading lists. It does nothing for anonymous requests.
+func (h *handlerV1) fillPostsBookmarked(ctx context.Context, c *gin.Context, posts ...*models.Post) error {
+	payload, ok := getAuthPayload(c)
+	if !ok || len(posts) == 0 {
+		return nil
+	}
+
+	req := pbp.GetBookmarkedRequest{
+		UserId:  payload.UserID,
+		PostIds: make([]int64, 0, len(posts)),
+	}
+	for _, post := range posts {
+		req.PostIds = append(req.PostIds, post.ID)
+	}
+
+	resp, err := h.grpcClient.ReadingListService().GetBookmarked(ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	bookmarked := make(map[int64]bool, len(resp.PostIds))
+	for _, id := range resp.PostIds {
+		bookmarked[id] = true
+	}
+
+	for _, post := range posts {
+		post.Bookmarked = bookmarked[post.ID]
+	}
+
+	return nil
+}
+
+func parseReadingListModel(list *pbp.ReadingList) models.ReadingList {
+	return models.ReadingList{
+		ID:         list.Id,
+		UserID:     list.UserId,
+		Name:       list.Name,
+		IsDefault:  list.IsDefault,
+		IsPublic:   list.IsPublic,
+		PostsCount: list.PostsCount,
+		CreatedAt:  list.CreatedAt,
+		UpdatedAt:  list.UpdatedAt,
+	}
+}
@@ -0,0 +1,359 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	searchUsers      = "users"
+	searchPosts      = "posts"
+	searchCategories = "categories"
+
+	// searchTimeout bounds the slowest backend; sections that are not ready
+	// by then are reported as unavailable.
+	searchTimeout = 3 * time.Second
+)
+
+// @Security ApiKeyAuth
+// @Router /search [get]
+// @Summary Search
+// @Description Search users, published posts and categories at once. Every type has its own section,
+// @Description paged separately: users_page, posts_page and categories_page page one section and default to page.
+// @Description Use type to get further pages of one section only.
+// @Description The backends page the matches, newest posts first, and each page is then ranked by relevance,
+// @Description so a better match can still come on a later page.
+// @Description If some backends fail the other sections are still returned, with a warning.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param filter query models.SearchParams false "Filter"
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} models.ErrorResponse
+func (h *handlerV1) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" || utf8.RuneCountInString(query) > 100 {
+		c.JSON(http.StatusBadRequest, errorResponse(errors.New("q must be between 1 and 100 characters long")))
+		return
+	}
+
+	params, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	types, err := parseSearchTypes(c.Query("type"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sections := make(map[string]*models.GetAllParams, len(types))
+	for kind := range types {
+		sections[kind], err = searchSectionParams(c, kind, params)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+
+	var (
+		response models.SearchResponse
+		matcher  = newSearchMatcher(query)
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+	)
+
+	// every section is written by its own goroutine only
+	run := func(kind string, search func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := search()
+			if err != nil {
+				log.Printf("failed to search %s: %v", kind, err)
+				mu.Lock()
+				response.Warnings = append(response.Warnings, fmt.Sprintf("%s results are temporarily unavailable", kind))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	if types[searchUsers] {
+		run(searchUsers, func() (err error) {
+			response.Users, err = h.searchUsers(ctx, query, sections[searchUsers], matcher)
+			return err
+		})
+	}
+	if types[searchPosts] {
+		run(searchPosts, func() (err error) {
+			response.Posts, err = h.searchPosts(ctx, c, query, sections[searchPosts], matcher)
+			return err
+		})
+	}
+	if types[searchCategories] {
+		run(searchCategories, func() (err error) {
+			response.Categories, err = h.searchCategories(ctx, query, sections[searchCategories], matcher)
+			return err
+		})
+	}
+	wg.Wait()
+
+	sort.Strings(response.Warnings)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func parseSearchTypes(value string) (map[string]bool, error) {
+	types := make(map[string]bool)
+
+	for _, t := range strings.Split(value, ",") {
+		switch t = strings.TrimSpace(t); t {
+		case "":
+		case searchUsers, searchPosts, searchCategories:
+			types[t] = true
+		default:
+			return nil, fmt.Errorf("unknown search type: %s", t)
+		}
+	}
+
+	if len(types) == 0 {
+		types[searchUsers] = true
+		types[searchPosts] = true
+		types[searchCategories] = true
+	}
+
+	return types, nil
+}
+
+// searchSectionParams returns the paging of one section: its own page when
+// one is given, the shared page otherwise.
+func searchSectionParams(c *gin.Context, kind string, params *models.GetAllParams) (*models.GetAllParams, error) {
+	section := *params
+
+	value := c.Query(kind + "_page")
+	if value == "" {
+		return &section, nil
+	}
+
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 1 {
+		return nil, fmt.Errorf("%s_page must be a positive number", kind)
+	}
+	section.Page = int32(page)
+
+	return &section, nil
+}
+
+func (h *handlerV1) searchUsers(ctx context.Context, query string, params *models.GetAllParams, m *searchMatcher) (*models.SearchUsersSection, error) {
+	result, err := h.grpcClient.UserService().GetAll(ctx, &pbu.GetAllUsersRequest{
+		Limit:          params.Limit,
+		Page:           params.Page,
+		Search:         query,
+		ExcludeDeleted: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	section := models.SearchUsersSection{
+		Items: make([]*models.SearchUserResult, 0, len(result.Users)),
+		Count: result.Count,
+		Page:  params.Page,
+	}
+
+	scores := make(map[*models.SearchUserResult]int, len(result.Users))
+	for _, user := range result.Users {
+		name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+		if user.Username != "" {
+			name += " (@" + user.Username + ")"
+		}
+
+		item := &models.SearchUserResult{
+			User:      h.parsePostAuthor(user),
+			Highlight: m.highlight(name),
+		}
+		scores[item] = m.score(user.Username) + m.score(user.FirstName+" "+user.LastName)
+		section.Items = append(section.Items, item)
+	}
+
+	sort.SliceStable(section.Items, func(i, j int) bool {
+		return scores[section.Items[i]] > scores[section.Items[j]]
+	})
+
+	return &section, nil
+}
+
+func (h *handlerV1) searchPosts(ctx context.Context, c *gin.Context, query string, params *models.GetAllParams, m *searchMatcher) (*models.SearchPostsSection, error) {
+	result, err := h.grpcClient.PostService().GetAll(ctx, &pbp.GetAllPostsRequest{
+		Limit:  params.Limit,
+		Page:   params.Page,
+		Search: query,
+		Status: postStatusPublished,
+		SortBy: "date",
+		Order:  "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := h.getPostsResponse(result).Posts
+	err = h.fillPostsLikeInfo(ctx, c, posts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.fillPostsBookmarked(ctx, c, posts...)
+	if err != nil {
+		return nil, err
+	}
+
+	section := models.SearchPostsSection{
+		Items: make([]*models.SearchPostResult, 0, len(posts)),
+		Count: result.Count,
+		Page:  params.Page,
+	}
+
+	scores := make(map[*models.SearchPostResult]int, len(posts))
+	for _, post := range posts {
+		item := &models.SearchPostResult{
+			Post:             post,
+			TitleHighlight:   m.highlight(post.Title),
+			ExcerptHighlight: m.highlight(post.Excerpt),
+		}
+		// a match in the title weighs more than one in the body
+		scores[item] = 2*m.score(post.Title) + m.score(post.Excerpt)
+		section.Items = append(section.Items, item)
+	}
+
+	sort.SliceStable(section.Items, func(i, j int) bool {
+		return scores[section.Items[i]] > scores[section.Items[j]]
+	})
+
+	return &section, nil
+}
+
+func (h *handlerV1) searchCategories(ctx context.Context, query string, params *models.GetAllParams, m *searchMatcher) (*models.SearchCategoriesSection, error) {
+	result, err := h.grpcClient.CategoryService().GetAll(ctx, &pbp.GetAllCategoriesRequest{
+		Limit:  params.Limit,
+		Page:   params.Page,
+		Search: query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	section := models.SearchCategoriesSection{
+		Items: make([]*models.SearchCategoryResult, 0, len(result.Categories)),
+		Count: result.Count,
+		Page:  params.Page,
+	}
+
+	scores := make(map[*models.SearchCategoryResult]int, len(result.Categories))
+	for _, category := range result.Categories {
+		item := &models.SearchCategoryResult{
+			Category:  parseCategoryModel(category),
+			Highlight: m.highlight(category.Title),
+		}
+		scores[item] = m.score(category.Title)
+		section.Items = append(section.Items, item)
+	}
+
+	sort.SliceStable(section.Items, func(i, j int) bool {
+		return scores[section.Items[i]] > scores[section.Items[j]]
+	})
+
+	return &section, nil
+}
+
+// searchMatcher ranks and highlights results by the words of the query,
+// ignoring case.
+type searchMatcher struct {
+	query string
+	terms []string
+	re    *regexp.Regexp
+}
+
+func newSearchMatcher(query string) *searchMatcher {
+	m := searchMatcher{
+		query: strings.ToLower(query),
+		terms: strings.Fields(strings.ToLower(query)),
+	}
+
+	// longer terms first so that the regexp prefers the longest match
+	sort.Slice(m.terms, func(i, j int) bool {
+		return len(m.terms[i]) > len(m.terms[j])
+	})
+
+	quoted := make([]string, 0, len(m.terms))
+	for _, term := range m.terms {
+		quoted = append(quoted, regexp.QuoteMeta(term))
+	}
+	m.re = regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	return &m
+}
+
+// score prefers an exact match, then a prefix match, then the number of
+// query words the text contains.
+func (m *searchMatcher) score(text string) int {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	score := 0
+	switch {
+	case text == m.query:
+		score += 100
+	case strings.HasPrefix(text, m.query):
+		score += 50
+	case strings.Contains(text, m.query):
+		score += 20
+	}
+
+	for _, term := range m.terms {
+		if strings.Contains(text, term) {
+			score += 5
+		}
+	}
+
+	return score
+}
+
+// highlight escapes text for HTML and wraps the matched terms in <mark>.
+func (m *searchMatcher) highlight(text string) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, loc := range m.re.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		b.WriteString("</mark>")
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+
+	return b.String()
+}
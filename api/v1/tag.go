@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/slug"
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /tags [get]
+// @Summary Get all tags
+// @Description Get tags with their usage counts, most used first.
+// @Description search matches tag prefixes, for autocomplete.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param filter query models.GetAllParams false "Filter"
+// @Success 200 {object} models.GetAllTagsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetAllTags(c *gin.Context) {
+	req, err := validateGetAllParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := h.grpcClient.TagService().GetAll(context.Background(), &pbp.GetAllTagsRequest{
+		Limit:  req.Limit,
+		Page:   req.Page,
+		Search: slug.Make(req.Search),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := models.GetAllTagsResponse{
+		Tags:  make([]*models.Tag, 0),
+		Count: result.Count,
+	}
+
+	for _, tag := range result.Tags {
+		response.Tags = append(response.Tags, &models.Tag{
+			Slug:       tag.Slug,
+			PostsCount: tag.PostsCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Router /tags/{slug}/posts [get]
+// @Summary Get posts by tag
+// @Description Get published posts with the tag
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param slug path string true "Tag"
+// @Param filter query models.GetAllPostsParams false "Filter"
+// @Param expand query []string false "Embed related resources" collectionFormat(csv) Enums(author, category)
+// @Success 200 {object} models.GetAllPostsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetTagPosts(c *gin.Context) {
+	req, err := validateGetAllPostsParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	req.Tag = slug.Make(c.Param("slug"))
+	if req.Tag == "" {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrInvalidTag))
+		return
+	}
+
+	h.listPosts(c, req)
+}
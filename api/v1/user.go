@@ -2,22 +2,34 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbn "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/notification_service"
 	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// @Security ApiKeyAuth
 // @Router /users [post]
 // @Summary Create a user
-// @Description Create a user
+// @Description Create a user. Only superadmins can create superadmins
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param user body models.CreateUserRequest true "User"
 // @Success 201 {object} models.User
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) CreateUser(c *gin.Context) {
 	var (
@@ -30,10 +42,29 @@ func (h *handlerV1) CreateUser(c *gin.Context) {
 		return
 	}
 
+	if req.Type == "" {
+		req.Type = userTypeUser
+	}
+	if req.Type == userTypeSuperadmin {
+		payload, ok := getAuthPayload(c)
+		if !ok || payload.UserType != userTypeSuperadmin {
+			c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
+			return
+		}
+	}
+
+	if req.Username != "" {
+		err = h.checkUsernameAvailable(req.Username, 0)
+		if err != nil {
+			c.JSON(usernameErrorStatus(err), errorResponse(err))
+			return
+		}
+	}
+
 	user, err := h.grpcClient.UserService().Create(context.Background(), &pbu.User{
 		FirstName:       req.FirstName,
 		LastName:        req.LastName,
-		PhoneNumber:     req.PhoneNumber,
+		PhoneNumber:     h.phoneE164(req.PhoneNumber),
 		Email:           req.Email,
 		Gender:          req.Gender,
 		Password:        req.Password,
@@ -46,53 +77,57 @@ func (h *handlerV1) CreateUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, parseUserModel(user))
+	c.JSON(http.StatusCreated, h.userResponse(user, userViewFor(c, user.Id)))
 }
 
-// @Router /users [put]
+// @Security ApiKeyAuth
+// @Router /users/{id} [patch]
 // @Summary Update a user
-// @Description Update a user
+// @Description Update a user. Only fields present in the body are changed.
+// @Description Users can update themselves, superadmins can update anyone
 // @Tags user
 // @Accept json
 // @Produce json
+// @Param id path int true "ID"
 // @Param user body models.UpdateUserRequest true "User"
 // @Success 200 {object} models.User
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) UpdateUser(c *gin.Context) {
-	var (
-		req models.UpdateUserRequest
-	)
-
-	err := c.ShouldBindJSON(&req)
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	user, err := h.grpcClient.UserService().Create(context.Background(), &pbu.User{
-		FirstName:       req.FirstName,
-		LastName:        req.LastName,
-		PhoneNumber:     req.PhoneNumber,
-		Gender:          req.Gender,
-		Username:        req.Username,
-		ProfileImageUrl: req.ProfileImageUrl,
-	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	if payload.UserID != int64(id) && payload.UserType != userTypeSuperadmin {
+		c.JSON(http.StatusForbidden, errorResponse(ErrForbidden))
 		return
 	}
 
-	c.JSON(http.StatusOK, parseUserModel(user))
+	h.updateUser(c, int64(id))
 }
 
+// @Security ApiKeyAuth
 // @Router /users/{id} [get]
 // @Summary Get user by id
-// @Description Get user by id
+// @Description Get user by id. Private fields are returned to the user themselves and to superadmins only
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param id path int true "ID"
 // @Success 200 {object} models.User
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) GetUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -103,52 +138,129 @@ func (h *handlerV1) GetUser(c *gin.Context) {
 
 	resp, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: int64(id)})
 	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, parseUserModel(resp))
+	view := userViewFor(c, resp.Id)
+	if isHiddenUser(resp, view) {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userResponse(resp, view))
 }
 
+// @Security ApiKeyAuth
 // @Router /users/email/{email} [get]
 // @Summary Get user by email
-// @Description Get user by email
+// @Description Get user by email. Private fields are returned to the user themselves and to superadmins only
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param email path string true "Email"
 // @Success 200 {object} models.User
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) GetUserByEmail(c *gin.Context) {
 	email := c.Param("email")
 
 	resp, err := h.grpcClient.UserService().GetByEmail(context.Background(), &pbu.GetByEmailRequest{Email: email})
 	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, parseUserModel(resp))
+	view := userViewFor(c, resp.Id)
+	if isHiddenUser(resp, view) {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userResponse(resp, view))
 }
 
-func parseUserModel(user *pbu.User) models.User {
-	return models.User{
+type userView int
+
+// Views a user can be returned in, from the least to the most detailed.
+const (
+	// userViewPublic is the profile anyone can see.
+	userViewPublic userView = iota
+	// userViewSelf adds contact details and the account type for the user
+	// themselves.
+	userViewSelf
+	// userViewAdmin is every field, for superadmins.
+	userViewAdmin
+)
+
+// userViewFor picks the view of the user with the given id for the caller.
+func userViewFor(c *gin.Context, userID int64) userView {
+	payload, ok := getAuthPayload(c)
+	switch {
+	case !ok:
+		return userViewPublic
+	case payload.UserType == userTypeSuperadmin:
+		return userViewAdmin
+	case payload.UserID == userID:
+		return userViewSelf
+	default:
+		return userViewPublic
+	}
+}
+
+// isHiddenUser reports whether a soft deleted user is hidden from a caller
+// with the given view. The user and superadmins can still see the profile
+// until it is purged.
+func isHiddenUser(user *pbu.User, view userView) bool {
+	return user.DeletedAt != "" && view == userViewPublic
+}
+
+func parseUserModel(user *pbu.User, view userView) models.User {
+	u := models.User{
 		ID:              user.Id,
 		FirstName:       user.FirstName,
 		LastName:        user.LastName,
-		PhoneNumber:     user.PhoneNumber,
-		Email:           user.Email,
-		Gender:          user.Gender,
 		Username:        user.Username,
 		ProfileImageUrl: user.ProfileImageUrl,
-		Type:            user.Type,
 		CreatedAt:       user.CreatedAt,
+		FollowersCount:  user.FollowersCount,
+		FollowingCount:  user.FollowingCount,
 	}
+
+	if view >= userViewSelf {
+		u.Email = user.Email
+		u.PhoneNumber = user.PhoneNumber
+		u.Gender = user.Gender
+		u.Type = user.Type
+		u.PhoneVerified = user.PhoneVerified
+		u.DeletedAt = user.DeletedAt
+	}
+
+	return u
 }
 
+// userResponse maps the user for a profile response, where users without a
+// profile image get the generated avatar.
+func (h *handlerV1) userResponse(user *pbu.User, view userView) models.User {
+	u := parseUserModel(user, view)
+	u.ProfileImageUrl = h.profileImageURL(user)
+
+	return u
+}
+
+// @Security ApiKeyAuth
 // @Router /users [get]
 // @Summary Get all users
-// @Description Get all users
+// @Description Get all users. Private fields are returned to superadmins only.
+// @Description For superadmins a search that is a valid phone number finds the user with that number
 // @Tags user
 // @Accept json
 // @Produce json
@@ -162,41 +274,58 @@ func (h *handlerV1) GetAllUsers(c *gin.Context) {
 		return
 	}
 
-	result, err := h.grpcClient.UserService().GetAll(context.Background(), &pbu.GetAllUsersRequest{
-		Page:   req.Page,
-		Limit:  req.Limit,
-		Search: req.Search,
-	})
+	filter := pbu.GetAllUsersRequest{
+		Page:           req.Page,
+		Limit:          req.Limit,
+		Search:         req.Search,
+		ExcludeDeleted: true,
+	}
+
+	// phone numbers are private, so only superadmins may look users up by
+	// them
+	if payload, ok := getAuthPayload(c); ok && payload.UserType == userTypeSuperadmin && req.Search != "" {
+		if phone, err := normalizePhone(req.Search, h.cfg.PhoneDefaultRegion); err == nil {
+			filter.Search = ""
+			filter.PhoneNumber = phone
+		}
+	}
+
+	result, err := h.grpcClient.UserService().GetAll(context.Background(), &filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, getUsersResponse(result))
+	c.JSON(http.StatusOK, h.getUsersResponse(c, result))
 }
 
-func getUsersResponse(data *pbu.GetAllUsersResponse) *models.GetAllUsersResponse {
+func (h *handlerV1) getUsersResponse(c *gin.Context, data *pbu.GetAllUsersResponse) *models.GetAllUsersResponse {
 	response := models.GetAllUsersResponse{
 		Users: make([]*models.User, 0),
 		Count: data.Count,
 	}
 
 	for _, user := range data.Users {
-		u := parseUserModel(user)
+		u := h.userResponse(user, userViewFor(c, user.Id))
 		response.Users = append(response.Users, &u)
 	}
 
 	return &response
 }
 
+// @Security ApiKeyAuth
 // @Router /users/{id} [delete]
 // @Summary Delete user
-// @Description Delete user
+// @Description Soft delete a user: the profile and posts are hidden at once and the account
+// @Description is purged when the restore window is over. Superadmins only
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param id path int true "ID"
-// @Success 200 {object} models.ResponseOK
+// @Success 200 {object} models.UserDeletionResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 func (h *handlerV1) DeleteUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -205,13 +334,292 @@ func (h *handlerV1) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	_, err = h.grpcClient.UserService().Delete(context.Background(), &pbu.IdRequest{Id: int64(id)})
+	h.deleteUser(c, int64(id))
+}
+
+// @Security ApiKeyAuth
+// @Router /users/me [get]
+// @Summary Get current user
+// @Description Get the profile of the authenticated user, including private fields
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) GetMe(c *gin.Context) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	user, err := h.grpcClient.UserService().Get(context.Background(), &pbu.IdRequest{Id: payload.UserID})
 	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	c.JSON(http.StatusOK, h.userResponse(user, userViewFor(c, user.Id)))
+}
+
+// @Security ApiKeyAuth
+// @Router /users/me [patch]
+// @Summary Update current user
+// @Description Update the profile of the authenticated user. Only fields present in the body are changed
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param user body models.UpdateUserRequest true "User"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) UpdateMe(c *gin.Context) {
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	h.updateUser(c, payload.UserID)
+}
+
+// updateUser sends the fields present in the request body to the user
+// service along with a field mask naming them, so that the others are
+// left untouched.
+func (h *handlerV1) updateUser(c *gin.Context, id int64) {
+	var (
+		req models.UpdateUserRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user := pbu.User{Id: id}
+	mask := field_mask.FieldMask{}
+
+	if req.FirstName != nil {
+		user.FirstName = *req.FirstName
+		mask.Paths = append(mask.Paths, "first_name")
+	}
+	if req.LastName != nil {
+		user.LastName = *req.LastName
+		mask.Paths = append(mask.Paths, "last_name")
+	}
+	if req.PhoneNumber != nil {
+		user.PhoneNumber = h.phoneE164(*req.PhoneNumber)
+		mask.Paths = append(mask.Paths, "phone_number")
+	}
+	if req.Gender != nil {
+		user.Gender = *req.Gender
+		mask.Paths = append(mask.Paths, "gender")
+	}
+	if req.Username != nil {
+		err = h.checkUsernameAvailable(*req.Username, id)
+		if err != nil {
+			c.JSON(usernameErrorStatus(err), errorResponse(err))
+			return
+		}
+		user.Username = *req.Username
+		mask.Paths = append(mask.Paths, "username")
+	}
+	if req.ProfileImageUrl != nil {
+		user.ProfileImageUrl = *req.ProfileImageUrl
+		mask.Paths = append(mask.Paths, "profile_image_url")
+	}
+
+	if len(mask.Paths) == 0 {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrNothingToUpdate))
+		return
+	}
+
+	resp, err := h.grpcClient.UserService().Update(context.Background(), &pbu.UpdateUserRequest{
+		User:       &user,
+		UpdateMask: &mask,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userResponse(resp, userViewFor(c, resp.Id)))
+}
+
+// @Security ApiKeyAuth
+// @Router /users/me [delete]
+// @Summary Delete current user
+// @Description Delete the account of the authenticated user. The current password is required.
+// @Description The account can be restored until restore_before, after that it is purged
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body models.DeleteMeRequest true "Password"
+// @Success 200 {object} models.UserDeletionResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) DeleteMe(c *gin.Context) {
+	var (
+		req models.DeleteMeRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	if !h.verifyCurrentPassword(c, payload.UserID, req.Password) {
+		return
+	}
+
+	h.deleteUser(c, payload.UserID)
+}
+
+// @Security ApiKeyAuth
+// @Router /users/me/password [post]
+// @Summary Change password
+// @Description Change the password of the authenticated user. All other sessions are signed out
+// @Description and a notification email is sent. Too many wrong current passwords lock the user out for a while
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body models.UpdatePasswordRequest true "Passwords"
+// @Success 200 {object} models.ResponseOK
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ChangePassword(c *gin.Context) {
+	var (
+		req models.UpdatePasswordRequest
+	)
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payload, ok := getAuthPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	err = validatePassword(req.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrSamePassword))
+		return
+	}
+
+	if !h.verifyCurrentPassword(c, payload.UserID, req.CurrentPassword) {
+		return
+	}
+
+	_, err = h.grpcClient.AuthService().ChangePassword(context.Background(), &pbu.ChangePasswordRequest{
+		UserId:   payload.UserID,
+		Password: req.NewPassword,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// keep the session the password was changed from
+	_, err = h.grpcClient.AuthService().RevokeSessions(context.Background(), &pbu.RevokeSessionsRequest{
+		UserId:        payload.UserID,
+		ExceptTokenId: payload.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the password is already changed, so a failed email is not an error
+	_, err = h.grpcClient.NotificationService().SendEmail(context.Background(), &pbn.SendEmailRequest{
+		To:      payload.Email,
+		Type:    "password_changed",
+		Subject: "Your password was changed",
+		Body: map[string]string{
+			"changed_at": time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		log.Printf("failed to send password changed email to user %d: %v", payload.UserID, err)
+	}
+
 	c.JSON(http.StatusOK, models.ResponseOK{
-		Message: "success",
+		Message: "password has been changed",
+	})
+}
+
+// verifyCurrentPassword writes the error response itself and reports
+// whether password is the user's current one. Every check counts towards
+// the brute-force lockout of the user until one succeeds.
+func (h *handlerV1) verifyCurrentPassword(c *gin.Context, userID int64, password string) bool {
+	key := fmt.Sprintf("password:%d", userID)
+
+	if wait := h.bruteForce.Attempt(key); wait > 0 {
+		setRetryAfter(c, wait)
+		c.JSON(http.StatusTooManyRequests, errorResponse(ErrTooManyAttempts))
+		return false
+	}
+
+	err := h.checkPassword(userID, password)
+	if err != nil {
+		if errors.Is(err, ErrWrongPassword) {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	h.bruteForce.Reset(key)
+
+	return true
+}
+
+// checkPassword returns ErrWrongPassword when password is not the user's
+// current one.
+func (h *handlerV1) checkPassword(userID int64, password string) error {
+	_, err := h.grpcClient.AuthService().CheckPassword(context.Background(), &pbu.CheckPasswordRequest{
+		UserId:   userID,
+		Password: password,
 	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+		return ErrWrongPassword
+	default:
+		return err
+	}
 }
@@ -0,0 +1,477 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	bulkFormatCSV    = "csv"
+	bulkFormatNDJSON = "ndjson"
+
+	importStatusCreated = "created"
+	importStatusValid   = "valid"
+	importStatusFailed  = "failed"
+
+	exportPageSize = 100
+)
+
+var (
+	ErrBulkFormat        = errors.New("format must be csv or ndjson")
+	ErrTooManyRows       = errors.New("file has too many rows")
+	ErrDuplicateEmail    = errors.New("email is used by an earlier row")
+	ErrDuplicateUsername = errors.New("username is used by an earlier row")
+)
+
+// importColumns are the CSV columns an import understands, named like the
+// fields of models.CreateUserRequest.
+var importColumns = map[string]func(req *models.CreateUserRequest, value string){
+	"first_name":        func(req *models.CreateUserRequest, v string) { req.FirstName = v },
+	"last_name":         func(req *models.CreateUserRequest, v string) { req.LastName = v },
+	"phone_number":      func(req *models.CreateUserRequest, v string) { req.PhoneNumber = v },
+	"email":             func(req *models.CreateUserRequest, v string) { req.Email = v },
+	"gender":            func(req *models.CreateUserRequest, v string) { req.Gender = v },
+	"username":          func(req *models.CreateUserRequest, v string) { req.Username = v },
+	"profile_image_url": func(req *models.CreateUserRequest, v string) { req.ProfileImageUrl = v },
+	"type":              func(req *models.CreateUserRequest, v string) { req.Type = v },
+	"password":          func(req *models.CreateUserRequest, v string) { req.Password = v },
+}
+
+type exportColumn struct {
+	name  string
+	value func(user *models.User) interface{}
+}
+
+// exportColumns in the order they are written when no columns are asked for.
+var exportColumns = []exportColumn{
+	{"id", func(u *models.User) interface{} { return u.ID }},
+	{"first_name", func(u *models.User) interface{} { return u.FirstName }},
+	{"last_name", func(u *models.User) interface{} { return u.LastName }},
+	{"username", func(u *models.User) interface{} { return u.Username }},
+	{"email", func(u *models.User) interface{} { return u.Email }},
+	{"phone_number", func(u *models.User) interface{} { return u.PhoneNumber }},
+	{"gender", func(u *models.User) interface{} { return u.Gender }},
+	{"type", func(u *models.User) interface{} { return u.Type }},
+	{"profile_image_url", func(u *models.User) interface{} { return u.ProfileImageUrl }},
+	{"created_at", func(u *models.User) interface{} { return u.CreatedAt }},
+	{"followers_count", func(u *models.User) interface{} { return u.FollowersCount }},
+	{"following_count", func(u *models.User) interface{} { return u.FollowingCount }},
+}
+
+type importRow struct {
+	req models.CreateUserRequest
+	err error
+}
+
+// @Security ApiKeyAuth
+// @Router /users/import [post]
+// @Summary Import users
+// @Description Create users from a CSV file with a header row or from NDJSON, one user per line.
+// @Description Every row is validated like POST /users and reported on its own, so valid rows are
+// @Description created even when others fail. With dry_run nothing is created. Superadmins only
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Users (csv or ndjson)"
+// @Param format query string false "File format, taken from the file extension by default" Enums(csv, ndjson)
+// @Param dry_run query bool false "Validate only"
+// @Success 200 {object} models.ImportUsersResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ImportUsers(c *gin.Context) {
+	maxSize := h.cfg.UserImportMaxSize
+
+	// leave some room for the multipart envelope
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrFileTooLarge))
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse(ErrFileTooLarge))
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = importFormatOf(header.Filename)
+	}
+
+	var rows []*importRow
+	switch format {
+	case bulkFormatCSV:
+		rows, err = readImportCSV(file)
+	case bulkFormatNDJSON:
+		rows, err = readImportNDJSON(file)
+	default:
+		err = ErrBulkFormat
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if len(rows) > h.cfg.UserImportMaxRows {
+		c.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("%w: at most %d are allowed", ErrTooManyRows, h.cfg.UserImportMaxRows)))
+		return
+	}
+
+	response := models.ImportUsersResponse{
+		DryRun: c.Query("dry_run") == "true",
+		Total:  len(rows),
+		Rows:   make([]*models.ImportUserRow, 0, len(rows)),
+	}
+
+	var (
+		emails    = make(map[string]bool)
+		usernames = make(map[string]bool)
+	)
+	for i, row := range rows {
+		result := models.ImportUserRow{
+			Row:   i + 1,
+			Email: row.req.Email,
+		}
+
+		id, err := h.importUser(row, emails, usernames, response.DryRun)
+		switch {
+		case err != nil:
+			result.Status = importStatusFailed
+			result.Error = err.Error()
+			response.Failed++
+		case response.DryRun:
+			result.Status = importStatusValid
+			response.Valid++
+		default:
+			result.Status = importStatusCreated
+			result.ID = id
+			response.Created++
+		}
+
+		response.Rows = append(response.Rows, &result)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// importUser validates the row against the rules of CreateUser and against
+// the earlier rows of the file, then creates the user unless dryRun is set.
+func (h *handlerV1) importUser(row *importRow, emails, usernames map[string]bool, dryRun bool) (int64, error) {
+	if row.err != nil {
+		return 0, row.err
+	}
+
+	req := row.req
+	err := binding.Validator.ValidateStruct(&req)
+	if err != nil {
+		return 0, err
+	}
+	if req.Type == "" {
+		req.Type = userTypeUser
+	}
+
+	email := strings.ToLower(req.Email)
+	if emails[email] {
+		return 0, ErrDuplicateEmail
+	}
+	emails[email] = true
+
+	if req.Username != "" {
+		username := strings.ToLower(req.Username)
+		if usernames[username] {
+			return 0, ErrDuplicateUsername
+		}
+		usernames[username] = true
+
+		err = h.checkUsernameAvailable(req.Username, 0)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if h.isRegistered(req.Email) {
+		return 0, ErrEmailExists
+	}
+
+	if dryRun {
+		return 0, nil
+	}
+
+	user, err := h.grpcClient.UserService().Create(context.Background(), &pbu.User{
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		PhoneNumber:     h.phoneE164(req.PhoneNumber),
+		Email:           req.Email,
+		Gender:          req.Gender,
+		Password:        req.Password,
+		Username:        req.Username,
+		ProfileImageUrl: req.ProfileImageUrl,
+		Type:            req.Type,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return user.Id, nil
+}
+
+func importFormatOf(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return bulkFormatCSV
+	case ".ndjson", ".jsonl":
+		return bulkFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// readImportCSV fails only when the file as a whole cannot be read. Rows
+// with the wrong number of fields are reported on their own.
+func readImportCSV(r io.Reader) ([]*importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv file is empty")
+		}
+		return nil, err
+	}
+
+	setters := make([]func(*models.CreateUserRequest, string), len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		setter, ok := importColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column: %s", name)
+		}
+		setters[i] = setter
+	}
+
+	var rows []*importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		var row importRow
+		if err != nil {
+			if !errors.Is(err, csv.ErrFieldCount) {
+				return nil, err
+			}
+			row.err = fmt.Errorf("row has %d fields, the header has %d", len(record), len(header))
+		} else {
+			for i, value := range record {
+				setters[i](&row.req, unescapeCSVCell(strings.TrimSpace(value)))
+			}
+		}
+
+		rows = append(rows, &row)
+	}
+}
+
+// readImportNDJSON reports lines that are not a valid user object on their
+// own and skips blank lines.
+func readImportNDJSON(r io.Reader) ([]*importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+	var rows []*importRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var row importRow
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.DisallowUnknownFields()
+		row.err = decoder.Decode(&row.req)
+
+		rows = append(rows, &row)
+	}
+
+	return rows, scanner.Err()
+}
+
+// @Security ApiKeyAuth
+// @Router /users/export [get]
+// @Summary Export users
+// @Description Download all users as CSV with a header row or as NDJSON, one user per line.
+// @Description The file is streamed page by page. Soft deleted users are left out. Superadmins only
+// @Tags user
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "File format" Enums(csv, ndjson) default(csv)
+// @Param columns query []string false "Columns to export, all by default" collectionFormat(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) ExportUsers(c *gin.Context) {
+	format := c.DefaultQuery("format", bulkFormatCSV)
+	if format != bulkFormatCSV && format != bulkFormatNDJSON {
+		c.JSON(http.StatusBadRequest, errorResponse(ErrBulkFormat))
+		return
+	}
+
+	columns, err := parseExportColumns(c.Query("columns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the first page is fetched before anything is written, so that a
+	// failing user service still gets a proper error response
+	result, err := h.grpcClient.UserService().GetAll(context.Background(), &pbu.GetAllUsersRequest{
+		Limit:          exportPageSize,
+		Page:           1,
+		ExcludeDeleted: true,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var (
+		write func(user *models.User) error
+		flush = func() {}
+	)
+	if format == bulkFormatCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+
+		writer := csv.NewWriter(c.Writer)
+		flush = writer.Flush
+
+		names := make([]string, 0, len(columns))
+		for _, column := range columns {
+			names = append(names, column.name)
+		}
+		err = writer.Write(names)
+
+		write = func(user *models.User) error {
+			record := make([]string, 0, len(columns))
+			for _, column := range columns {
+				record = append(record, escapeCSVCell(fmt.Sprint(column.value(user))))
+			}
+			writer.Write(record)
+			return writer.Error()
+		}
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+
+		encoder := json.NewEncoder(c.Writer)
+		write = func(user *models.User) error {
+			record := make(map[string]interface{}, len(columns))
+			for _, column := range columns {
+				record[column.name] = column.value(user)
+			}
+			return encoder.Encode(record)
+		}
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, format))
+	c.Status(http.StatusOK)
+
+	for page := int32(1); err == nil; page++ {
+		if page > 1 {
+			result, err = h.grpcClient.UserService().GetAll(context.Background(), &pbu.GetAllUsersRequest{
+				Limit:          exportPageSize,
+				Page:           page,
+				ExcludeDeleted: true,
+			})
+			if err != nil {
+				break
+			}
+		}
+
+		for _, user := range result.Users {
+			u := parseUserModel(user, userViewAdmin)
+			err = write(&u)
+			if err != nil {
+				break
+			}
+		}
+		flush()
+		c.Writer.Flush()
+
+		if len(result.Users) < exportPageSize || page*exportPageSize >= result.Count {
+			return
+		}
+	}
+
+	// the status is already sent, all that is left is to cut the file short
+	log.Printf("failed to export users: %v", err)
+}
+
+// escapeCSVCell keeps spreadsheets from running a cell as a formula by
+// prefixing it with a quote. Phone numbers in E.164 are escaped too.
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// unescapeCSVCell undoes escapeCSVCell, so that exported files can be
+// imported again.
+func unescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@", rune(value[1])) {
+		return value[1:]
+	}
+
+	return value
+}
+
+func parseExportColumns(value string) ([]exportColumn, error) {
+	if value == "" {
+		return exportColumns, nil
+	}
+
+	var columns []exportColumn
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+
+		found := false
+		for _, column := range exportColumns {
+			if column.name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column: %s", name)
+		}
+	}
+
+	return columns, nil
+}
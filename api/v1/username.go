@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api/models"
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInvalidUsername  = errors.New("username must be 3 to 30 characters long, start with a letter and contain only letters, digits and underscores")
+	ErrReservedUsername = errors.New("username is reserved")
+	ErrUsernameTaken    = errors.New("username is already taken")
+
+	usernameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{2,29}$`)
+
+	// reservedUsernames would clash with routes or mislead other users.
+	reservedUsernames = map[string]bool{
+		"admin": true, "administrator": true, "api": true, "auth": true,
+		"categories": true, "feed": true, "help": true, "login": true,
+		"logout": true, "me": true, "media": true, "moderator": true,
+		"posts": true, "register": true, "root": true, "search": true,
+		"settings": true, "superadmin": true, "support": true, "swagger": true,
+		"system": true, "tags": true, "users": true,
+	}
+)
+
+// GetUserByUsername serves GET /v1/@:username with the profile of the user,
+// ignoring case. A username the user had before redirects to the current one
+// for a grace period after the rename.
+//
+// It is not in the swagger docs: swag does not accept "@" in router paths.
+func (h *handlerV1) GetUserByUsername(c *gin.Context) {
+	resp, err := h.grpcClient.UserService().GetByUsername(context.Background(), &pbu.GetByUsernameRequest{
+		Username: c.Param("username"),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	view := userViewFor(c, resp.User.Id)
+	if isHiddenUser(resp.User, view) {
+		c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+		return
+	}
+
+	if resp.Previous {
+		if !h.isUsernameRedirectActive(resp) {
+			c.JSON(http.StatusNotFound, errorResponse(ErrNotFound))
+			return
+		}
+
+		// not permanent: once the grace period is over the old username
+		// may be taken by someone else
+		c.Redirect(http.StatusFound, "/v1/@"+resp.User.Username)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userResponse(resp.User, view))
+}
+
+// @Security ApiKeyAuth
+// @Router /users/username-available [get]
+// @Summary Check username availability
+// @Description Check whether a username is valid and free. For authenticated users their own usernames count as available
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param u query string true "Username"
+// @Success 200 {object} models.UsernameAvailableResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+func (h *handlerV1) CheckUsernameAvailable(c *gin.Context) {
+	username := c.Query("u")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, errorResponse(errors.New("u is required")))
+		return
+	}
+
+	var userID int64
+	if payload, ok := getAuthPayload(c); ok {
+		userID = payload.UserID
+	}
+
+	response := models.UsernameAvailableResponse{
+		Username:  username,
+		Available: true,
+	}
+
+	err := h.checkUsernameAvailable(username, userID)
+	if err != nil {
+		if usernameErrorStatus(err) == http.StatusInternalServerError {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		response.Available = false
+		response.Reason = err.Error()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func validateUsername(username string) error {
+	if !usernameRegexp.MatchString(username) {
+		return ErrInvalidUsername
+	}
+
+	if reservedUsernames[strings.ToLower(username)] {
+		return ErrReservedUsername
+	}
+
+	return nil
+}
+
+// checkUsernameAvailable validates the username and makes sure nobody but
+// the given user has it, ignoring case. Previous usernames stay taken while
+// they still redirect.
+func (h *handlerV1) checkUsernameAvailable(username string, userID int64) error {
+	err := validateUsername(username)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.grpcClient.UserService().GetByUsername(context.Background(), &pbu.GetByUsernameRequest{
+		Username: username,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if resp.User.Id == userID {
+		return nil
+	}
+
+	if resp.Previous && !h.isUsernameRedirectActive(resp) {
+		return nil
+	}
+
+	return ErrUsernameTaken
+}
+
+func (h *handlerV1) isUsernameRedirectActive(resp *pbu.GetByUsernameResponse) bool {
+	renamedAt, err := time.Parse(time.RFC3339, resp.RenamedAt)
+	if err != nil {
+		return false
+	}
+
+	return time.Since(renamedAt) < h.cfg.UsernameRedirectGrace
+}
+
+func usernameErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUsernameTaken):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidUsername), errors.Is(err, ErrReservedUsername):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
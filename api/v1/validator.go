@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// RegisterValidators adds the custom binding tags:
+//
+//	phone: a valid phone number, in international format or local to
+//	       defaultRegion. An empty value passes so that a phone number can
+//	       be cleared.
+func RegisterValidators(defaultRegion string) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("binding validator is not go-playground/validator")
+	}
+
+	return v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		number := fl.Field().String()
+		if number == "" {
+			return true
+		}
+
+		_, err := normalizePhone(number, defaultRegion)
+		return err == nil
+	})
+}
+
+// normalizePhone returns the number in E.164 format. Numbers without a
+// country code are read as local to defaultRegion.
+func normalizePhone(number, defaultRegion string) (string, error) {
+	parsed, err := phonenumbers.Parse(number, defaultRegion)
+	if err != nil || !phonenumbers.IsValidNumber(parsed) {
+		return "", ErrInvalidPhone
+	}
+
+	return phonenumbers.Format(parsed, phonenumbers.E164), nil
+}
+
+// phoneE164 normalizes a number that passed the phone binding tag. An empty
+// number stays empty, so that it can clear the phone of a user.
+func (h *handlerV1) phoneE164(number string) string {
+	if number == "" {
+		return ""
+	}
+
+	normalized, err := normalizePhone(number, h.cfg.PhoneDefaultRegion)
+	if err != nil {
+		return number
+	}
+
+	return normalized
+}
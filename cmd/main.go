@@ -1,10 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/api"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/config"
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/account_purge"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/avatar"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/brute_force"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/cooldown"
 	grpcPkg "github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/grpc_client"
-	"log"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/markdown"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/media"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/scheduler"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/storage"
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/view_counter"
 
 	_ "github.com/lib/pq"
 )
@@ -17,13 +36,117 @@ func main() {
 		log.Fatalf("failed to get grpc connections: %v", err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	viewCounter := view_counter.New(view_counter.Options{
+		Window:        cfg.ViewDedupWindow,
+		FlushInterval: cfg.ViewFlushInterval,
+		BatchSize:     cfg.ViewFlushBatchSize,
+		MaxSeen:       cfg.ViewDedupMaxSize,
+		Flush: func(ctx context.Context, views map[int64]int32) error {
+			req := pbp.IncrementViewsRequest{}
+			for postID, count := range views {
+				req.Views = append(req.Views, &pbp.PostViews{PostId: postID, Count: count})
+			}
+			_, err := grpcConn.PostService().IncrementViews(ctx, &req)
+			return err
+		},
+	})
+	go viewCounter.Run(ctx)
+
+	publisher := scheduler.New(grpcConn.PostService(), cfg.SchedulerInterval)
+	go publisher.Run(ctx)
+
+	purger := account_purge.New(grpcConn.UserService(), cfg.AccountDeletionWindow, cfg.AccountPurgeInterval)
+	go purger.Run(ctx)
+
+	mediaStorage, err := newStorage(ctx, &cfg)
+	if err != nil {
+		log.Fatalf("failed to init media storage: %v", err)
+	}
+
+	mediaCache, err := media.NewCache(cfg.MediaCacheDir, cfg.MediaCacheMaxSize)
+	if err != nil {
+		log.Fatalf("failed to init media cache: %v", err)
+	}
+
+	avatars, err := avatar.New(cfg.AvatarStyle, cfg.AvatarPalette)
+	if err != nil {
+		log.Fatalf("failed to init avatar generator: %v", err)
+	}
+
+	// avatars get their own budget so that they cannot evict resized media
+	avatarCache, err := media.NewCache(filepath.Join(cfg.MediaCacheDir, "avatars"), cfg.AvatarCacheMaxSize)
+	if err != nil {
+		log.Fatalf("failed to init avatar cache: %v", err)
+	}
+
 	apiServer := api.New(&api.RouterOptions{
-		Cfg:        &cfg,
-		GrpcClient: grpcConn,
+		Cfg:         &cfg,
+		GrpcClient:  grpcConn,
+		ViewCounter: viewCounter,
+		Storage:     mediaStorage,
+		MediaCache:  mediaCache,
+		Resizer:     media.NewResizer(cfg.MediaResizeConcurrency),
+		Publisher:   publisher,
+		Markdown:    markdown.NewRenderer(cfg.MarkdownCacheSize),
+		BruteForce: brute_force.New(brute_force.Options{
+			MaxAttempts: cfg.BruteForceMaxAttempts,
+			Window:      cfg.BruteForceWindow,
+			Lockout:     cfg.BruteForceLockout,
+		}),
+		// A code that ran out of attempts stays locked for as long as a code
+		// lives, so it has expired by the time the lockout is over. Sending a
+		// new code resets the lockout.
+		CodeAttempts: brute_force.New(brute_force.Options{
+			MaxAttempts: cfg.VerificationMaxAttempts,
+			Window:      cfg.VerificationCodeTTL,
+			Lockout:     cfg.VerificationCodeTTL,
+		}),
+		ResendCooldown: cooldown.New(cfg.VerificationResendCooldown),
+		Avatars:        avatars,
+		AvatarCache:    avatarCache,
 	})
 
-	err = apiServer.Run(cfg.HttpPort)
+	srv := &http.Server{
+		Addr:    cfg.HttpPort,
+		Handler: apiServer,
+	}
+
+	go func() {
+		err := srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("failed to run server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = srv.Shutdown(shutdownCtx)
 	if err != nil {
-		log.Fatalf("failed to run server: %v", err)
+		log.Printf("failed to shutdown server: %v", err)
 	}
+
+	<-viewCounter.Done()
+	<-publisher.Done()
+	<-purger.Done()
+}
+
+func newStorage(ctx context.Context, cfg *config.Config) (storage.Storage, error) {
+	if cfg.MediaStorage == "s3" {
+		return storage.NewS3(ctx, storage.S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			UseSSL:    cfg.S3UseSSL,
+		})
+	}
+
+	return storage.NewLocal(cfg.MediaLocalDir)
 }
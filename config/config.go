@@ -1,6 +1,10 @@
 package config
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
@@ -11,6 +15,59 @@ type Config struct {
 	UserServiceHost     string
 	PostServiceGrpcPort string
 	PostServiceHost     string
+
+	NotificationServiceGrpcPort string
+	NotificationServiceHost     string
+
+	ViewDedupWindow    time.Duration
+	ViewFlushInterval  time.Duration
+	ViewFlushBatchSize int
+	ViewDedupMaxSize   int
+
+	SchedulerInterval time.Duration
+	MaxPostTags       int
+
+	MarkdownCacheSize int
+
+	UsernameRedirectGrace time.Duration
+
+	AccountDeletionWindow time.Duration
+	AccountPurgeInterval  time.Duration
+
+	UserImportMaxSize int64
+	UserImportMaxRows int
+
+	PhoneDefaultRegion string
+
+	AvatarStyle        string
+	AvatarPalette      []string
+	AvatarCacheMaxSize int64
+
+	VerificationResendCooldown time.Duration
+	VerificationMaxAttempts    int
+	VerificationCodeTTL        time.Duration
+
+	BruteForceMaxAttempts int
+	BruteForceWindow      time.Duration
+	BruteForceLockout     time.Duration
+
+	MediaStorage   string
+	MediaLocalDir  string
+	MediaMaxSize   int64
+	MediaPublicURL string
+
+	MediaCacheDir     string
+	MediaCacheMaxSize int64
+	MediaPresetSizes  []int
+
+	MediaResizeConcurrency int
+
+	S3Endpoint  string
+	S3AccessKey string
+	S3SecretKey string
+	S3Bucket    string
+	S3Region    string
+	S3UseSSL    bool
 }
 
 func Load(path string) Config {
@@ -19,13 +76,130 @@ func Load(path string) Config {
 	conf := viper.New()
 	conf.AutomaticEnv()
 
+	conf.SetDefault("VIEW_DEDUP_WINDOW", "30m")
+	conf.SetDefault("VIEW_FLUSH_INTERVAL", "10s")
+	conf.SetDefault("VIEW_FLUSH_BATCH_SIZE", 500)
+	conf.SetDefault("VIEW_DEDUP_MAX_SIZE", 100000)
+	conf.SetDefault("SCHEDULER_INTERVAL", "1m")
+	conf.SetDefault("MAX_POST_TAGS", 5)
+	conf.SetDefault("MARKDOWN_CACHE_SIZE", 1000)
+	conf.SetDefault("USERNAME_REDIRECT_GRACE", "720h")
+
+	conf.SetDefault("ACCOUNT_DELETION_WINDOW", "720h")
+	conf.SetDefault("ACCOUNT_PURGE_INTERVAL", "1h")
+
+	conf.SetDefault("USER_IMPORT_MAX_SIZE", 5<<20)
+	conf.SetDefault("USER_IMPORT_MAX_ROWS", 1000)
+
+	conf.SetDefault("PHONE_DEFAULT_REGION", "UZ")
+
+	conf.SetDefault("AVATAR_STYLE", "initials")
+	conf.SetDefault("AVATAR_PALETTE", "")
+	conf.SetDefault("AVATAR_CACHE_MAX_SIZE", 32<<20)
+	conf.SetDefault("VERIFICATION_RESEND_COOLDOWN", "1m")
+	conf.SetDefault("VERIFICATION_MAX_ATTEMPTS", 5)
+	conf.SetDefault("VERIFICATION_CODE_TTL", "15m")
+
+	conf.SetDefault("BRUTE_FORCE_MAX_ATTEMPTS", 5)
+	conf.SetDefault("BRUTE_FORCE_WINDOW", "15m")
+	conf.SetDefault("BRUTE_FORCE_LOCKOUT", "15m")
+	conf.SetDefault("MEDIA_STORAGE", "local")
+	conf.SetDefault("MEDIA_LOCAL_DIR", "./media")
+	conf.SetDefault("MEDIA_MAX_SIZE", 5<<20)
+	conf.SetDefault("MEDIA_PUBLIC_URL", "http://localhost:8000")
+	conf.SetDefault("MEDIA_CACHE_DIR", "./media_cache")
+	conf.SetDefault("MEDIA_CACHE_MAX_SIZE", 512<<20)
+	conf.SetDefault("MEDIA_PRESET_SIZES", "128,320,640,1280")
+	conf.SetDefault("MEDIA_RESIZE_CONCURRENCY", 0)
+
 	cfg := Config{
 		HttpPort:            conf.GetString("HTTP_PORT"),
 		UserServiceHost:     conf.GetString("USER_SERVICE_HOST"),
 		UserServiceGrpcPort: conf.GetString("USER_SERVICE_GRPC_PORT"),
 		PostServiceHost:     conf.GetString("POST_SERVICE_HOST"),
 		PostServiceGrpcPort: conf.GetString("POST_SERVICE_GRPC_PORT"),
+
+		NotificationServiceHost:     conf.GetString("NOTIFICATION_SERVICE_HOST"),
+		NotificationServiceGrpcPort: conf.GetString("NOTIFICATION_SERVICE_GRPC_PORT"),
+
+		ViewDedupWindow:    conf.GetDuration("VIEW_DEDUP_WINDOW"),
+		ViewFlushInterval:  conf.GetDuration("VIEW_FLUSH_INTERVAL"),
+		ViewFlushBatchSize: conf.GetInt("VIEW_FLUSH_BATCH_SIZE"),
+		ViewDedupMaxSize:   conf.GetInt("VIEW_DEDUP_MAX_SIZE"),
+
+		SchedulerInterval: conf.GetDuration("SCHEDULER_INTERVAL"),
+		MaxPostTags:       conf.GetInt("MAX_POST_TAGS"),
+
+		MarkdownCacheSize: conf.GetInt("MARKDOWN_CACHE_SIZE"),
+
+		UsernameRedirectGrace: conf.GetDuration("USERNAME_REDIRECT_GRACE"),
+
+		AccountDeletionWindow: conf.GetDuration("ACCOUNT_DELETION_WINDOW"),
+		AccountPurgeInterval:  conf.GetDuration("ACCOUNT_PURGE_INTERVAL"),
+
+		UserImportMaxSize: conf.GetInt64("USER_IMPORT_MAX_SIZE"),
+		UserImportMaxRows: conf.GetInt("USER_IMPORT_MAX_ROWS"),
+
+		PhoneDefaultRegion: conf.GetString("PHONE_DEFAULT_REGION"),
+
+		AvatarStyle:        conf.GetString("AVATAR_STYLE"),
+		AvatarPalette:      parseStringList(conf.GetString("AVATAR_PALETTE")),
+		AvatarCacheMaxSize: conf.GetInt64("AVATAR_CACHE_MAX_SIZE"),
+
+		VerificationResendCooldown: conf.GetDuration("VERIFICATION_RESEND_COOLDOWN"),
+		VerificationMaxAttempts:    conf.GetInt("VERIFICATION_MAX_ATTEMPTS"),
+		VerificationCodeTTL:        conf.GetDuration("VERIFICATION_CODE_TTL"),
+
+		BruteForceMaxAttempts: conf.GetInt("BRUTE_FORCE_MAX_ATTEMPTS"),
+		BruteForceWindow:      conf.GetDuration("BRUTE_FORCE_WINDOW"),
+		BruteForceLockout:     conf.GetDuration("BRUTE_FORCE_LOCKOUT"),
+
+		MediaStorage:   conf.GetString("MEDIA_STORAGE"),
+		MediaLocalDir:  conf.GetString("MEDIA_LOCAL_DIR"),
+		MediaMaxSize:   conf.GetInt64("MEDIA_MAX_SIZE"),
+		MediaPublicURL: conf.GetString("MEDIA_PUBLIC_URL"),
+
+		MediaCacheDir:     conf.GetString("MEDIA_CACHE_DIR"),
+		MediaCacheMaxSize: conf.GetInt64("MEDIA_CACHE_MAX_SIZE"),
+		MediaPresetSizes:  parseIntList(conf.GetString("MEDIA_PRESET_SIZES")),
+
+		MediaResizeConcurrency: conf.GetInt("MEDIA_RESIZE_CONCURRENCY"),
+
+		S3Endpoint:  conf.GetString("S3_ENDPOINT"),
+		S3AccessKey: conf.GetString("S3_ACCESS_KEY"),
+		S3SecretKey: conf.GetString("S3_SECRET_KEY"),
+		S3Bucket:    conf.GetString("S3_BUCKET"),
+		S3Region:    conf.GetString("S3_REGION"),
+		S3UseSSL:    conf.GetBool("S3_USE_SSL"),
 	}
 
 	return cfg
 }
+
+func parseIntList(value string) []int {
+	var result []int
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		result = append(result, n)
+	}
+
+	return result
+}
+
+func parseStringList(value string) []string {
+	var result []string
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -8,6 +8,7 @@ package post_service
 
 import (
 	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,6 +25,12 @@ const _ = grpc.SupportPackageIsVersion7
 type CategoryServiceClient interface {
 	Create(ctx context.Context, in *Category, opts ...grpc.CallOption) (*Category, error)
 	Get(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	GetAll(ctx context.Context, in *GetAllCategoriesRequest, opts ...grpc.CallOption) (*GetAllCategoriesResponse, error)
+	Update(ctx context.Context, in *Category, opts ...grpc.CallOption) (*Category, error)
+	Delete(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Follow(ctx context.Context, in *CategoryFollowRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Unfollow(ctx context.Context, in *CategoryFollowRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetFollowed(ctx context.Context, in *GetFollowedCategoriesRequest, opts ...grpc.CallOption) (*GetAllCategoriesResponse, error)
 }
 
 type categoryServiceClient struct {
@@ -52,12 +59,72 @@ func (c *categoryServiceClient) Get(ctx context.Context, in *GetCategoryRequest,
 	return out, nil
 }
 
+func (c *categoryServiceClient) GetAll(ctx context.Context, in *GetAllCategoriesRequest, opts ...grpc.CallOption) (*GetAllCategoriesResponse, error) {
+	out := new(GetAllCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Update(ctx context.Context, in *Category, opts ...grpc.CallOption) (*Category, error) {
+	out := new(Category)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Delete(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Follow(ctx context.Context, in *CategoryFollowRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/Follow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Unfollow(ctx context.Context, in *CategoryFollowRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/Unfollow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetFollowed(ctx context.Context, in *GetFollowedCategoriesRequest, opts ...grpc.CallOption) (*GetAllCategoriesResponse, error) {
+	out := new(GetAllCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/genproto.CategoryService/GetFollowed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CategoryServiceServer is the server API for CategoryService service.
 // All implementations must embed UnimplementedCategoryServiceServer
 // for forward compatibility
 type CategoryServiceServer interface {
 	Create(context.Context, *Category) (*Category, error)
 	Get(context.Context, *GetCategoryRequest) (*Category, error)
+	GetAll(context.Context, *GetAllCategoriesRequest) (*GetAllCategoriesResponse, error)
+	Update(context.Context, *Category) (*Category, error)
+	Delete(context.Context, *GetCategoryRequest) (*empty.Empty, error)
+	Follow(context.Context, *CategoryFollowRequest) (*empty.Empty, error)
+	Unfollow(context.Context, *CategoryFollowRequest) (*empty.Empty, error)
+	GetFollowed(context.Context, *GetFollowedCategoriesRequest) (*GetAllCategoriesResponse, error)
 	mustEmbedUnimplementedCategoryServiceServer()
 }
 
@@ -71,6 +138,24 @@ func (UnimplementedCategoryServiceServer) Create(context.Context, *Category) (*C
 func (UnimplementedCategoryServiceServer) Get(context.Context, *GetCategoryRequest) (*Category, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedCategoryServiceServer) GetAll(context.Context, *GetAllCategoriesRequest) (*GetAllCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedCategoryServiceServer) Update(context.Context, *Category) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedCategoryServiceServer) Delete(context.Context, *GetCategoryRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCategoryServiceServer) Follow(context.Context, *CategoryFollowRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Follow not implemented")
+}
+func (UnimplementedCategoryServiceServer) Unfollow(context.Context, *CategoryFollowRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unfollow not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetFollowed(context.Context, *GetFollowedCategoriesRequest) (*GetAllCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowed not implemented")
+}
 func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
 
 // UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +205,114 @@ func _CategoryService_Get_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetAll(ctx, req.(*GetAllCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Category)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Update(ctx, req.(*Category))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Delete(ctx, req.(*GetCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Follow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryFollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Follow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/Follow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Follow(ctx, req.(*CategoryFollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Unfollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryFollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Unfollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/Unfollow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Unfollow(ctx, req.(*CategoryFollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetFollowed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetFollowed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.CategoryService/GetFollowed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetFollowed(ctx, req.(*GetFollowedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +328,30 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _CategoryService_Get_Handler,
 		},
+		{
+			MethodName: "GetAll",
+			Handler:    _CategoryService_GetAll_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _CategoryService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CategoryService_Delete_Handler,
+		},
+		{
+			MethodName: "Follow",
+			Handler:    _CategoryService_Follow_Handler,
+		},
+		{
+			MethodName: "Unfollow",
+			Handler:    _CategoryService_Unfollow_Handler,
+		},
+		{
+			MethodName: "GetFollowed",
+			Handler:    _CategoryService_GetFollowed_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "category_service.proto",
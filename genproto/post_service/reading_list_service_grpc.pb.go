@@ -0,0 +1,466 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: reading_list_service.proto
+
+package post_service
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReadingListServiceClient is the client API for ReadingListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReadingListServiceClient interface {
+	Create(ctx context.Context, in *ReadingList, opts ...grpc.CallOption) (*ReadingList, error)
+	Get(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error)
+	GetOrCreateDefault(ctx context.Context, in *GetDefaultReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error)
+	GetAll(ctx context.Context, in *GetAllReadingListsRequest, opts ...grpc.CallOption) (*GetAllReadingListsResponse, error)
+	Update(ctx context.Context, in *ReadingList, opts ...grpc.CallOption) (*ReadingList, error)
+	Delete(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	AddPost(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RemovePost(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetPosts(ctx context.Context, in *GetReadingListPostsRequest, opts ...grpc.CallOption) (*GetReadingListPostsResponse, error)
+	Reorder(ctx context.Context, in *ReorderReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetBookmarked(ctx context.Context, in *GetBookmarkedRequest, opts ...grpc.CallOption) (*GetBookmarkedResponse, error)
+}
+
+type readingListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReadingListServiceClient(cc grpc.ClientConnInterface) ReadingListServiceClient {
+	return &readingListServiceClient{cc}
+}
+
+func (c *readingListServiceClient) Create(ctx context.Context, in *ReadingList, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) Get(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) GetOrCreateDefault(ctx context.Context, in *GetDefaultReadingListRequest, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/GetOrCreateDefault", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) GetAll(ctx context.Context, in *GetAllReadingListsRequest, opts ...grpc.CallOption) (*GetAllReadingListsResponse, error) {
+	out := new(GetAllReadingListsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) Update(ctx context.Context, in *ReadingList, opts ...grpc.CallOption) (*ReadingList, error) {
+	out := new(ReadingList)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) Delete(ctx context.Context, in *GetReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) AddPost(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/AddPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) RemovePost(ctx context.Context, in *ReadingListPostRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/RemovePost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) GetPosts(ctx context.Context, in *GetReadingListPostsRequest, opts ...grpc.CallOption) (*GetReadingListPostsResponse, error) {
+	out := new(GetReadingListPostsResponse)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/GetPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) Reorder(ctx context.Context, in *ReorderReadingListRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/Reorder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readingListServiceClient) GetBookmarked(ctx context.Context, in *GetBookmarkedRequest, opts ...grpc.CallOption) (*GetBookmarkedResponse, error) {
+	out := new(GetBookmarkedResponse)
+	err := c.cc.Invoke(ctx, "/genproto.ReadingListService/GetBookmarked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReadingListServiceServer is the server API for ReadingListService service.
+// All implementations must embed UnimplementedReadingListServiceServer
+// for forward compatibility
+type ReadingListServiceServer interface {
+	Create(context.Context, *ReadingList) (*ReadingList, error)
+	Get(context.Context, *GetReadingListRequest) (*ReadingList, error)
+	GetOrCreateDefault(context.Context, *GetDefaultReadingListRequest) (*ReadingList, error)
+	GetAll(context.Context, *GetAllReadingListsRequest) (*GetAllReadingListsResponse, error)
+	Update(context.Context, *ReadingList) (*ReadingList, error)
+	Delete(context.Context, *GetReadingListRequest) (*empty.Empty, error)
+	AddPost(context.Context, *ReadingListPostRequest) (*empty.Empty, error)
+	RemovePost(context.Context, *ReadingListPostRequest) (*empty.Empty, error)
+	GetPosts(context.Context, *GetReadingListPostsRequest) (*GetReadingListPostsResponse, error)
+	Reorder(context.Context, *ReorderReadingListRequest) (*empty.Empty, error)
+	GetBookmarked(context.Context, *GetBookmarkedRequest) (*GetBookmarkedResponse, error)
+	mustEmbedUnimplementedReadingListServiceServer()
+}
+
+// UnimplementedReadingListServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReadingListServiceServer struct {
+}
+
+func (UnimplementedReadingListServiceServer) Create(context.Context, *ReadingList) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedReadingListServiceServer) Get(context.Context, *GetReadingListRequest) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedReadingListServiceServer) GetOrCreateDefault(context.Context, *GetDefaultReadingListRequest) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrCreateDefault not implemented")
+}
+func (UnimplementedReadingListServiceServer) GetAll(context.Context, *GetAllReadingListsRequest) (*GetAllReadingListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedReadingListServiceServer) Update(context.Context, *ReadingList) (*ReadingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedReadingListServiceServer) Delete(context.Context, *GetReadingListRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedReadingListServiceServer) AddPost(context.Context, *ReadingListPostRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPost not implemented")
+}
+func (UnimplementedReadingListServiceServer) RemovePost(context.Context, *ReadingListPostRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePost not implemented")
+}
+func (UnimplementedReadingListServiceServer) GetPosts(context.Context, *GetReadingListPostsRequest) (*GetReadingListPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosts not implemented")
+}
+func (UnimplementedReadingListServiceServer) Reorder(context.Context, *ReorderReadingListRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reorder not implemented")
+}
+func (UnimplementedReadingListServiceServer) GetBookmarked(context.Context, *GetBookmarkedRequest) (*GetBookmarkedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookmarked not implemented")
+}
+func (UnimplementedReadingListServiceServer) mustEmbedUnimplementedReadingListServiceServer() {}
+
+// UnsafeReadingListServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReadingListServiceServer will
+// result in compilation errors.
+type UnsafeReadingListServiceServer interface {
+	mustEmbedUnimplementedReadingListServiceServer()
+}
+
+func RegisterReadingListServiceServer(s grpc.ServiceRegistrar, srv ReadingListServiceServer) {
+	s.RegisterService(&ReadingListService_ServiceDesc, srv)
+}
+
+func _ReadingListService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).Create(ctx, req.(*ReadingList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).Get(ctx, req.(*GetReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_GetOrCreateDefault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDefaultReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).GetOrCreateDefault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/GetOrCreateDefault",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).GetOrCreateDefault(ctx, req.(*GetDefaultReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllReadingListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).GetAll(ctx, req.(*GetAllReadingListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).Update(ctx, req.(*ReadingList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).Delete(ctx, req.(*GetReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_AddPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingListPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).AddPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/AddPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).AddPost(ctx, req.(*ReadingListPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_RemovePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadingListPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).RemovePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/RemovePost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).RemovePost(ctx, req.(*ReadingListPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_GetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingListPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).GetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/GetPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).GetPosts(ctx, req.(*GetReadingListPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_Reorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderReadingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).Reorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/Reorder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).Reorder(ctx, req.(*ReorderReadingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReadingListService_GetBookmarked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookmarkedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadingListServiceServer).GetBookmarked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/genproto.ReadingListService/GetBookmarked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadingListServiceServer).GetBookmarked(ctx, req.(*GetBookmarkedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReadingListService_ServiceDesc is the grpc.ServiceDesc for ReadingListService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReadingListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "genproto.ReadingListService",
+	HandlerType: (*ReadingListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ReadingListService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ReadingListService_Get_Handler,
+		},
+		{
+			MethodName: "GetOrCreateDefault",
+			Handler:    _ReadingListService_GetOrCreateDefault_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _ReadingListService_GetAll_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ReadingListService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ReadingListService_Delete_Handler,
+		},
+		{
+			MethodName: "AddPost",
+			Handler:    _ReadingListService_AddPost_Handler,
+		},
+		{
+			MethodName: "RemovePost",
+			Handler:    _ReadingListService_RemovePost_Handler,
+		},
+		{
+			MethodName: "GetPosts",
+			Handler:    _ReadingListService_GetPosts_Handler,
+		},
+		{
+			MethodName: "Reorder",
+			Handler:    _ReadingListService_Reorder_Handler,
+		},
+		{
+			MethodName: "GetBookmarked",
+			Handler:    _ReadingListService_GetBookmarked_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reading_list_service.proto",
+}
@@ -0,0 +1,90 @@
+package account_purge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
+)
+
+const pageSize = 100
+
+// Purger hard-deletes soft deleted users once their restore window is
+// over. Like the post publisher it keeps no state: every run asks the user
+// service for the users deleted before the window started.
+type Purger struct {
+	users    pbu.UserServiceClient
+	window   time.Duration
+	interval time.Duration
+	done     chan struct{}
+}
+
+func New(users pbu.UserServiceClient, window, interval time.Duration) *Purger {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &Purger{
+		users:    users,
+		window:   window,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+// Run purges expired accounts every interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Done is closed once Run has returned.
+func (p *Purger) Done() <-chan struct{} {
+	return p.done
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.window).UTC().Format(time.RFC3339)
+
+	// Purged users drop out of the listing, so the first page is asked for
+	// again until it is short or nothing on it could be purged.
+	for {
+		resp, err := p.users.GetAll(ctx, &pbu.GetAllUsersRequest{
+			Limit:         pageSize,
+			Page:          1,
+			DeletedOnly:   true,
+			DeletedBefore: before,
+		})
+		if err != nil {
+			log.Printf("failed to get users pending deletion: %v", err)
+			return
+		}
+
+		purged := 0
+		for _, user := range resp.Users {
+			_, err := p.users.Delete(ctx, &pbu.IdRequest{Id: user.Id})
+			if err != nil {
+				log.Printf("failed to purge user %d: %v", user.Id, err)
+				continue
+			}
+			purged++
+		}
+
+		if len(resp.Users) < pageSize || purged == 0 {
+			return
+		}
+	}
+}
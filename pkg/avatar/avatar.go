@@ -0,0 +1,288 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	StyleInitials  = "initials"
+	StyleIdenticon = "identicon"
+
+	// identiconCells is the width and height of the identicon grid. Only
+	// the left half and the middle column are random, the right half
+	// mirrors them.
+	identiconCells = 5
+
+	svgSize = 128
+)
+
+var (
+	ErrUnknownStyle = errors.New("style must be one of: initials, identicon")
+
+	// DefaultPalette is used when no palette is configured.
+	DefaultPalette = []string{
+		"#1abc9c", "#2ecc71", "#3498db", "#9b59b6", "#34495e",
+		"#16a085", "#27ae60", "#2980b9", "#8e44ad", "#e67e22",
+		"#e74c3c", "#d35400", "#c0392b", "#7f8c8d",
+	}
+
+	foreground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	background = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+)
+
+// Generator draws default avatars. The same user, name and style always
+// give the same avatar: the color and the identicon pattern depend on the
+// user id only, so an avatar keeps its color when the user is renamed.
+type Generator struct {
+	style   string
+	palette []color.RGBA
+	// version changes with the palette, so that cached avatars drawn with
+	// an older palette are not served.
+	version string
+	font    *opentype.Font
+}
+
+// New returns a generator drawing style by default with the palette given
+// as "#rrggbb" colors.
+func New(style string, palette []string) (*Generator, error) {
+	if style == "" {
+		style = StyleInitials
+	}
+	if style != StyleInitials && style != StyleIdenticon {
+		return nil, ErrUnknownStyle
+	}
+
+	if len(palette) == 0 {
+		palette = DefaultPalette
+	}
+
+	g := Generator{
+		style: style,
+	}
+
+	h := fnv.New32a()
+	for _, hex := range palette {
+		c, err := parseHexColor(hex)
+		if err != nil {
+			return nil, err
+		}
+		g.palette = append(g.palette, c)
+		h.Write([]byte(hex))
+	}
+	g.version = strconv.FormatUint(uint64(h.Sum32()), 36)
+
+	f, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, err
+	}
+	g.font = f
+
+	return &g, nil
+}
+
+// Style returns the style to draw: the requested one, or the default when
+// none is requested.
+func (g *Generator) Style(requested string) (string, error) {
+	switch requested {
+	case "":
+		return g.style, nil
+	case StyleInitials, StyleIdenticon:
+		return requested, nil
+	default:
+		return "", ErrUnknownStyle
+	}
+}
+
+// Key identifies an avatar for caching. size is zero for SVG.
+func (g *Generator) Key(userID int64, name, style string, size int) string {
+	h := fnv.New32a()
+	h.Write([]byte(Initials(name)))
+
+	return fmt.Sprintf("avatar-%d-%s-%s-%x-%d", userID, style, g.version, h.Sum32(), size)
+}
+
+// SVG draws the avatar as a square SVG image.
+func (g *Generator) SVG(userID int64, name, style string) []byte {
+	seed := seedOf(userID)
+	fill := g.palette[seed%uint64(len(g.palette))]
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, svgSize, svgSize, svgSize, svgSize)
+
+	initials := Initials(name)
+	if style == StyleInitials && initials != "" {
+		fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`, svgSize, svgSize, hexOf(fill))
+		fmt.Fprintf(&b, `<text x="50%%" y="50%%" dy=".35em" text-anchor="middle" font-family="sans-serif" font-weight="bold" font-size="%d" fill="%s">%s</text>`,
+			svgSize*9/20, hexOf(foreground), html.EscapeString(initials))
+	} else {
+		fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`, svgSize, svgSize, hexOf(background))
+
+		cell := svgSize / (identiconCells + 1)
+		offset := (svgSize - cell*identiconCells) / 2
+		forEachIdenticonCell(seed, func(x, y int) {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+				offset+x*cell, offset+y*cell, cell, cell, hexOf(fill))
+		})
+	}
+
+	b.WriteString(`</svg>`)
+
+	return b.Bytes()
+}
+
+// PNG draws the avatar as a size by size PNG image.
+func (g *Generator) PNG(userID int64, name, style string, size int) ([]byte, error) {
+	seed := seedOf(userID)
+	fill := g.palette[seed%uint64(len(g.palette))]
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	// the font covers Latin, Greek and Cyrillic only, other scripts would
+	// be drawn as boxes
+	initials := Initials(name)
+	if style == StyleInitials && initials != "" && g.hasGlyphs(initials) {
+		draw.Draw(img, img.Bounds(), image.NewUniform(fill), image.Point{}, draw.Src)
+
+		err := g.drawText(img, initials, float64(size)*9/20)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+		cell := size / (identiconCells + 1)
+		offset := (size - cell*identiconCells) / 2
+		forEachIdenticonCell(seed, func(x, y int) {
+			r := image.Rect(offset+x*cell, offset+y*cell, offset+(x+1)*cell, offset+(y+1)*cell)
+			draw.Draw(img, r, image.NewUniform(fill), image.Point{}, draw.Src)
+		})
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// hasGlyphs reports whether the font has a glyph for every rune of text.
+func (g *Generator) hasGlyphs(text string) bool {
+	for _, r := range text {
+		// a nil buffer is safe for concurrent use
+		index, err := g.font.GlyphIndex(nil, r)
+		if err != nil || index == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// drawText centers text in img.
+func (g *Generator) drawText(img *image.RGBA, text string, fontSize float64) error {
+	face, err := opentype.NewFace(g.font, &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(foreground),
+		Face: face,
+	}
+
+	size := img.Bounds().Dx()
+	metrics := face.Metrics()
+	width := d.MeasureString(text)
+
+	d.Dot = fixed.Point26_6{
+		X: (fixed.I(size) - width) / 2,
+		Y: (fixed.I(size) + metrics.Ascent - metrics.Descent) / 2,
+	}
+	d.DrawString(text)
+
+	return nil
+}
+
+// Initials returns the first letters of the first two words of name, in
+// upper case.
+func Initials(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				initials = append(initials, unicode.ToUpper(r))
+				break
+			}
+		}
+		if len(initials) == 2 {
+			break
+		}
+	}
+
+	return string(initials)
+}
+
+func seedOf(userID int64) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatInt(userID, 10)))
+
+	return h.Sum64()
+}
+
+// forEachIdenticonCell calls fn for the filled cells of the identicon of
+// seed. The cells come from the bits above the low byte of the seed.
+func forEachIdenticonCell(seed uint64, fn func(x, y int)) {
+	bits := seed >> 8
+	half := (identiconCells + 1) / 2
+
+	for x := 0; x < half; x++ {
+		for y := 0; y < identiconCells; y++ {
+			if bits&(1<<uint(x*identiconCells+y)) == 0 {
+				continue
+			}
+			fn(x, y)
+			if mirror := identiconCells - 1 - x; mirror != x {
+				fn(mirror, y)
+			}
+		}
+	}
+}
+
+func parseHexColor(s string) (color.RGBA, error) {
+	s = strings.TrimSpace(s)
+
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || len(s) != 7 || s[0] != '#' {
+		return color.RGBA{}, fmt.Errorf("invalid palette color %q, want #rrggbb", s)
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+func hexOf(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,120 @@
+package brute_force
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepSize is the number of tracked keys above which expired entries are
+// dropped on the next failure.
+const sweepSize = 1000
+
+type Options struct {
+	// MaxAttempts is the number of failures allowed inside Window before
+	// the key is locked out.
+	MaxAttempts int
+	Window      time.Duration
+	Lockout     time.Duration
+}
+
+type entry struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// Limiter counts failed attempts per key, e.g. per user for password
+// checks, and locks the key out once there are too many of them.
+type Limiter struct {
+	opts    Options
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+func New(opts Options) *Limiter {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.Window <= 0 {
+		opts.Window = 15 * time.Minute
+	}
+	if opts.Lockout <= 0 {
+		opts.Lockout = 15 * time.Minute
+	}
+
+	return &Limiter{
+		opts:    opts,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Check returns how long the key stays locked out, or zero when it may
+// try again.
+func (l *Limiter) Check(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		return 0
+	}
+
+	wait := time.Until(e.lockedUntil)
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// Attempt reserves an attempt for the key before it is checked and returns
+// how long the key stays locked out, or zero when the attempt may go ahead.
+// The attempt counts as failed until Reset is called, so concurrent
+// attempts cannot get past the limit between a check and its failure.
+func (l *Limiter) Attempt(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if len(l.entries) > sweepSize {
+		l.sweep(now)
+	}
+
+	e, ok := l.entries[key]
+	if ok {
+		if wait := e.lockedUntil.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	if !ok || now.Sub(e.windowStart) > l.opts.Window {
+		e = &entry{windowStart: now}
+		l.entries[key] = e
+	}
+
+	e.failures++
+	if e.failures >= l.opts.MaxAttempts {
+		// this is the last attempt, the next ones are locked out
+		e.failures = 0
+		e.windowStart = now
+		e.lockedUntil = now.Add(l.opts.Lockout)
+	}
+
+	return 0
+}
+
+// Reset forgets the failures of the key after a successful attempt.
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+}
+
+// sweep must be called with mu held.
+func (l *Limiter) sweep(now time.Time) {
+	for key, e := range l.entries {
+		if now.Sub(e.windowStart) > l.opts.Window && now.After(e.lockedUntil) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cooldown
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepSize is the number of tracked keys above which expired entries are
+// dropped on the next start.
+const sweepSize = 1000
+
+// Cooldown makes a key wait for a fixed period after an action, e.g. after
+// a verification code is sent to an email.
+type Cooldown struct {
+	period time.Duration
+	mu     sync.Mutex
+	until  map[string]time.Time
+}
+
+func New(period time.Duration) *Cooldown {
+	if period <= 0 {
+		period = time.Minute
+	}
+
+	return &Cooldown{
+		period: period,
+		until:  make(map[string]time.Time),
+	}
+}
+
+// Start starts the cooldown of the key, replacing a running one.
+func (c *Cooldown) Start(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.until) > sweepSize {
+		c.sweep(now)
+	}
+
+	c.until[key] = now.Add(c.period)
+}
+
+// Remaining returns how long the cooldown of the key still runs, or zero
+// when there is none.
+func (c *Cooldown) Remaining(key string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wait := time.Until(c.until[key])
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// sweep must be called with mu held.
+func (c *Cooldown) sweep(now time.Time) {
+	for key, until := range c.until {
+		if now.After(until) {
+			delete(c.until, key)
+		}
+	}
+}
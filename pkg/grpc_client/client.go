@@ -3,6 +3,7 @@ package grpc_client
 import (
 	"fmt"
 	"github.com/MuhammadyusufAdhamov/medium_api_gateway/config"
+	pbn "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/notification_service"
 	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
 	pbu "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/user_service"
 	"google.golang.org/grpc"
@@ -12,8 +13,14 @@ import (
 type GrpcClientI interface {
 	UserService() pbu.UserServiceClient
 	AuthService() pbu.AuthServiceClient
+	FollowService() pbu.FollowServiceClient
 	PostService() pbp.PostServiceClient
 	CategoryService() pbp.CategoryServiceClient
+	LikeService() pbp.LikeServiceClient
+	CommentService() pbp.CommentServiceClient
+	TagService() pbp.TagServiceClient
+	ReadingListService() pbp.ReadingListServiceClient
+	NotificationService() pbn.NotificationServiceClient
 }
 
 type GrpcClient struct {
@@ -38,14 +45,28 @@ func New(cfg config.Config) (GrpcClientI, error) {
 		return nil, fmt.Errorf("post service dial host: %s port:%s err: %v",
 			cfg.PostServiceHost, cfg.PostServiceGrpcPort, err)
 	}
+	connNotificationService, err := grpc.Dial(
+		fmt.Sprintf("%s%s", cfg.NotificationServiceHost, cfg.NotificationServiceGrpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("notification service dial host: %s port:%s err: %v",
+			cfg.NotificationServiceHost, cfg.NotificationServiceGrpcPort, err)
+	}
 
 	return &GrpcClient{
 		cfg: cfg,
 		connections: map[string]interface{}{
-			"user_service":     pbu.NewUserServiceClient(connUserService),
-			"auth_service":     pbu.NewAuthServiceClient(connUserService),
-			"post_service":     pbp.NewPostServiceClient(connPostService),
-			"category_service": pbp.NewCategoryServiceClient(connPostService),
+			"user_service":         pbu.NewUserServiceClient(connUserService),
+			"auth_service":         pbu.NewAuthServiceClient(connUserService),
+			"follow_service":       pbu.NewFollowServiceClient(connUserService),
+			"post_service":         pbp.NewPostServiceClient(connPostService),
+			"category_service":     pbp.NewCategoryServiceClient(connPostService),
+			"like_service":         pbp.NewLikeServiceClient(connPostService),
+			"comment_service":      pbp.NewCommentServiceClient(connPostService),
+			"tag_service":          pbp.NewTagServiceClient(connPostService),
+			"reading_list_service": pbp.NewReadingListServiceClient(connPostService),
+			"notification_service": pbn.NewNotificationServiceClient(connNotificationService),
 		},
 	}, nil
 }
@@ -58,6 +79,10 @@ func (g *GrpcClient) AuthService() pbu.AuthServiceClient {
 	return g.connections["auth_service"].(pbu.AuthServiceClient)
 }
 
+func (g *GrpcClient) FollowService() pbu.FollowServiceClient {
+	return g.connections["follow_service"].(pbu.FollowServiceClient)
+}
+
 func (g *GrpcClient) PostService() pbp.PostServiceClient {
 	return g.connections["post_service"].(pbp.PostServiceClient)
 }
@@ -65,3 +90,23 @@ func (g *GrpcClient) PostService() pbp.PostServiceClient {
 func (g *GrpcClient) CategoryService() pbp.CategoryServiceClient {
 	return g.connections["category_service"].(pbp.CategoryServiceClient)
 }
+
+func (g *GrpcClient) LikeService() pbp.LikeServiceClient {
+	return g.connections["like_service"].(pbp.LikeServiceClient)
+}
+
+func (g *GrpcClient) CommentService() pbp.CommentServiceClient {
+	return g.connections["comment_service"].(pbp.CommentServiceClient)
+}
+
+func (g *GrpcClient) TagService() pbp.TagServiceClient {
+	return g.connections["tag_service"].(pbp.TagServiceClient)
+}
+
+func (g *GrpcClient) ReadingListService() pbp.ReadingListServiceClient {
+	return g.connections["reading_list_service"].(pbp.ReadingListServiceClient)
+}
+
+func (g *GrpcClient) NotificationService() pbn.NotificationServiceClient {
+	return g.connections["notification_service"].(pbn.NotificationServiceClient)
+}
@@ -0,0 +1,44 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/MuhammadyusufAdhamov/medium_api_gateway/pkg/slug"
+	"github.com/yuin/goldmark/ast"
+)
+
+// headingIDs generates heading anchors with slug.Make, so that headings in
+// any script get a readable id. The default generator of goldmark keeps
+// ASCII only. Repeated ids get a numeric suffix.
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{
+		used: map[string]bool{},
+	}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	id := slug.Make(string(value))
+	if id == "" {
+		if kind == ast.KindHeading {
+			id = "heading"
+		} else {
+			id = "id"
+		}
+	}
+
+	result := id
+	for i := 1; s.used[result]; i++ {
+		result = fmt.Sprintf("%s-%d", id, i)
+	}
+	s.used[result] = true
+
+	return []byte(result)
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.used[string(value)] = true
+}
@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+type TOCEntry struct {
+	Level int
+	ID    string
+	Title string
+}
+
+type Result struct {
+	HTML string
+	TOC  []TOCEntry
+}
+
+// Renderer converts Markdown to HTML that is safe to embed in a page.
+// Raw HTML in the source is dropped by the parser and the output is run
+// through an allow-list sanitizer on top of that.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+	cache  *lru
+	stats  *lru
+}
+
+func NewRenderer(cacheSize int) *Renderer {
+	if cacheSize <= 0 {
+		cacheSize = 1000
+	}
+
+	policy := bluemonday.UGCPolicy()
+	// fenced code blocks keep their language for client side highlighting
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// heading anchors
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{M}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+
+	return &Renderer{
+		md: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		),
+		policy: policy,
+		cache:  newLRU(cacheSize),
+		stats:  newLRU(cacheSize),
+	}
+}
+
+// Render renders source, reusing the result cached under revision. The
+// revision must change whenever the source does.
+func (r *Renderer) Render(revision, source string) (*Result, error) {
+	if result, ok := r.cache.get(revision); ok {
+		return result.(*Result), nil
+	}
+
+	src := []byte(source)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := r.md.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+
+	var toc []TOCEntry
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+		toc = append(toc, TOCEntry{
+			Level: heading.Level,
+			ID:    string(idBytes),
+			Title: string(heading.Text(src)),
+		})
+
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = r.md.Renderer().Render(&buf, src, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		HTML: r.policy.Sanitize(buf.String()),
+		TOC:  toc,
+	}
+	r.cache.put(revision, result)
+
+	return result, nil
+}
+
+// Analyze computes the stats of source like Analyze, reusing the stats
+// cached under revision. Lists analyze every post they return, so the
+// revision must change whenever the source does.
+func (r *Renderer) Analyze(revision, source string, excerptLen int) Stats {
+	key := fmt.Sprintf("%s:%d", revision, excerptLen)
+	if stats, ok := r.stats.get(key); ok {
+		return stats.(Stats)
+	}
+
+	stats := Analyze(source, excerptLen)
+	r.stats.put(key, stats)
+
+	return stats
+}
@@ -0,0 +1,78 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// WordsPerMinute is the average reading speed used for reading time.
+const WordsPerMinute = 200
+
+var (
+	plainMarkdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	stripPolicy   = bluemonday.StrictPolicy()
+)
+
+// Stats describes the plain text of a post body.
+type Stats struct {
+	WordCount          int
+	ReadingTimeMinutes int
+	Excerpt            string
+}
+
+// PlainText strips Markdown syntax and any HTML tags from source and
+// collapses whitespace.
+func PlainText(source string) string {
+	var buf bytes.Buffer
+	if err := plainMarkdown.Convert([]byte(source), &buf); err != nil {
+		buf.Reset()
+		buf.WriteString(source)
+	}
+
+	// keep words in adjacent blocks apart once the tags are gone
+	text := strings.ReplaceAll(buf.String(), "<", " <")
+	text = html.UnescapeString(stripPolicy.Sanitize(text))
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Analyze computes word count, reading time and an excerpt of at most
+// excerptLen characters.
+func Analyze(source string, excerptLen int) Stats {
+	text := PlainText(source)
+	words := len(strings.Fields(text))
+
+	return Stats{
+		WordCount:          words,
+		ReadingTimeMinutes: int(math.Ceil(float64(words) / WordsPerMinute)),
+		Excerpt:            Truncate(text, excerptLen),
+	}
+}
+
+// Truncate cuts text to at most n characters (not bytes), preferring a
+// word boundary, and appends an ellipsis when anything was cut.
+func Truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+
+	cut := n
+	for i := n; i > n/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
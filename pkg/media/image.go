@@ -0,0 +1,63 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+// MaxDimension is the largest accepted width or height of an image.
+const MaxDimension = 8000
+
+var (
+	ErrUnsupportedType = errors.New("unsupported media type")
+	ErrTooLarge        = errors.New("image dimensions are too large")
+)
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+type ImageInfo struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// DetectImage sniffs the real content type from the file bytes, ignoring
+// whatever the client claimed, and makes sure the image header decodes.
+func DetectImage(data []byte) (*ImageInfo, error) {
+	contentType := http.DetectContentType(data)
+
+	ext, ok := extensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrMalformedImage
+	}
+
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, ErrTooLarge
+	}
+
+	return &ImageInfo{
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       config.Width,
+		Height:      config.Height,
+	}, nil
+}
@@ -0,0 +1,293 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var ErrMalformedImage = errors.New("malformed image")
+
+// StripMetadata removes EXIF, XMP and text metadata from the image without
+// re-encoding the pixel data. Only a JPEG with an EXIF orientation is
+// re-encoded, rotated upright, since the orientation goes with the EXIF
+// data. Unknown content types are returned as is.
+func StripMetadata(contentType string, data []byte) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		data, err := orientJPEG(data)
+		if err != nil {
+			return nil, err
+		}
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	case "image/gif":
+		return stripGIF(data)
+	default:
+		return data, nil
+	}
+}
+
+// stripJPEG drops APP1-APP15 segments (except ICC profiles and Adobe color
+// transform info) and comments. Everything from the start of scan on is
+// copied verbatim.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xFF || i+1 >= len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// fill byte
+			i++
+			continue
+		case marker == 0xD9 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		// the length counts itself
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		if marker == 0xDA {
+			out.Write(data[i:])
+			break
+		}
+
+		if !dropJPEGSegment(marker, data[i+4:end]) {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	return out.Bytes(), nil
+}
+
+func dropJPEGSegment(marker byte, payload []byte) bool {
+	switch {
+	case marker == 0xFE:
+		return true
+	case marker == 0xE2:
+		return !bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00"))
+	case marker == 0xEE:
+		return !bytes.HasPrefix(payload, []byte("Adobe"))
+	case marker >= 0xE1 && marker <= 0xEF:
+		return true
+	}
+
+	return false
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, ErrMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		i = end
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+const (
+	webpFlagXMP  = 0x04
+	webpFlagEXIF = 0x08
+)
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+
+	i := 12
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			return nil, ErrMalformedImage
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if size > 0 {
+				chunk[8] &^= webpFlagEXIF | webpFlagXMP
+			}
+			out.Write(chunk)
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	result := out.Bytes()
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+
+	return result, nil
+}
+
+const (
+	gifExtension  = 0x21
+	gifImage      = 0x2C
+	gifTrailer    = 0x3B
+	gifComment    = 0xFE
+	gifAppExt     = 0xFF
+	gifColorTable = 0x80
+)
+
+// gifXMPApp is the application identifier and authentication code of XMP
+// application extensions.
+var gifXMPApp = []byte("XMP DataXMP")
+
+// stripGIF drops comment extensions and XMP application extensions. Other
+// extensions, e.g. the NETSCAPE2.0 one that makes an animation loop, are
+// kept.
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, ErrMalformedImage
+	}
+
+	// header, logical screen descriptor and global color table
+	i := 13
+	if data[10]&gifColorTable != 0 {
+		i += 3 << (1 + data[10]&0x07)
+	}
+	if i > len(data) {
+		return nil, ErrMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:i])
+
+	for i < len(data) {
+		start := i
+
+		switch data[i] {
+		case gifTrailer:
+			out.WriteByte(gifTrailer)
+			return out.Bytes(), nil
+		case gifExtension:
+			if i+2 > len(data) {
+				return nil, ErrMalformedImage
+			}
+			label := data[i+1]
+
+			end, err := skipGIFSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+			if label == gifComment {
+				continue
+			}
+			if label == gifAppExt && start+3+len(gifXMPApp) <= end && bytes.Equal(data[start+3:start+3+len(gifXMPApp)], gifXMPApp) {
+				continue
+			}
+		case gifImage:
+			// image descriptor, local color table and LZW minimum code size
+			if i+10 > len(data) {
+				return nil, ErrMalformedImage
+			}
+			i += 10
+			if data[i-1]&gifColorTable != 0 {
+				i += 3 << (1 + data[i-1]&0x07)
+			}
+			i++
+
+			end, err := skipGIFSubBlocks(data, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		default:
+			return nil, ErrMalformedImage
+		}
+
+		out.Write(data[start:i])
+	}
+
+	// the trailer is missing, like in many files in the wild
+	return out.Bytes(), nil
+}
+
+// skipGIFSubBlocks returns the index after the block terminator of the
+// sub-blocks starting at i.
+func skipGIFSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, ErrMalformedImage
+		}
+
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+}
@@ -0,0 +1,170 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+)
+
+const exifOrientationTag = 0x0112
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// orientJPEG rotates and flips the image upright according to its EXIF
+// orientation, so that the image still shows the right way up once the EXIF
+// data is stripped. ICC profiles are carried over; images without an
+// orientation are returned as is.
+func orientJPEG(data []byte) ([]byte, error) {
+	orientation, iccProfiles := jpegOrientation(data)
+	if orientation < 2 || orientation > 8 {
+		return data, nil
+	}
+
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrMalformedImage
+	}
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, orient(src, orientation), &jpeg.Options{Quality: 90})
+	if err != nil {
+		return nil, err
+	}
+
+	// the encoder writes no application segments, the profiles go right
+	// after the start of image marker
+	encoded := buf.Bytes()
+	out := bytes.NewBuffer(make([]byte, 0, len(encoded)))
+	out.Write(encoded[:2])
+	for _, segment := range iccProfiles {
+		out.Write(segment)
+	}
+	out.Write(encoded[2:])
+
+	return out.Bytes(), nil
+}
+
+// jpegOrientation returns the EXIF orientation of the image, or zero when
+// it has none, and its ICC profile segments. Malformed segments are left
+// for stripJPEG to report.
+func jpegOrientation(data []byte) (int, [][]byte) {
+	var (
+		orientation int
+		iccProfiles [][]byte
+	)
+
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, nil
+	}
+
+	i := 2
+	for i+4 <= len(data) && data[i] == 0xFF {
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+		if marker == 0xFF {
+			i++
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			break
+		}
+
+		payload := data[i+4 : end]
+		switch {
+		case marker == 0xE1 && orientation == 0 && bytes.HasPrefix(payload, exifHeader):
+			orientation = exifOrientation(payload[len(exifHeader):])
+		case marker == 0xE2 && bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00")):
+			iccProfiles = append(iccProfiles, data[i:end])
+		}
+		i = end
+	}
+
+	return orientation, iccProfiles
+}
+
+// exifOrientation reads the orientation from the first IFD of the TIFF
+// structure of EXIF data.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			// a SHORT, left aligned in the value field
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+
+	return 0
+}
+
+// orient transforms src for the EXIF orientation: 2 to 4 flip or turn it
+// around, 5 to 8 also swap the width and the height.
+func orient(src image.Image, orientation int) image.Image {
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	sw, sh := b.Dx(), b.Dy()
+	w, h := sw, sh
+	if orientation >= 5 {
+		w, h = sh, sw
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, w-1-x
+			case 7:
+				sx, sy = h-1-y, w-1-x
+			case 8:
+				sx, sy = h-1-y, x
+			}
+
+			s := rgba.PixOffset(sx, sy)
+			d := dst.PixOffset(x, y)
+			copy(dst.Pix[d:d+4], rgba.Pix[s:s+4])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,190 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"runtime"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	FitCover   = "cover"
+	FitContain = "contain"
+	FitFill    = "fill"
+
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+var ErrInvalidVariant = errors.New("invalid image variant")
+
+var formatContentTypes = map[string]string{
+	FormatJPEG: "image/jpeg",
+	FormatPNG:  "image/png",
+	FormatWebP: "image/webp",
+}
+
+// Variant describes a resized rendition of an image. A zero width or
+// height is derived from the other one keeping the aspect ratio.
+type Variant struct {
+	Width  int
+	Height int
+	Fit    string
+	Format string
+}
+
+// Key is a file name friendly identifier of the variant.
+func (v Variant) Key() string {
+	return fmt.Sprintf("%dx%d-%s.%s", v.Width, v.Height, v.Fit, v.Format)
+}
+
+func (v Variant) ContentType() string {
+	return formatContentTypes[v.Format]
+}
+
+// FormatOf returns the output format matching an uploaded content type.
+func FormatOf(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return FormatJPEG
+	case "image/webp":
+		return FormatWebP
+	default:
+		return FormatPNG
+	}
+}
+
+// OutputFormat returns the format a variant in the given format is encoded
+// in. Builds without cgo cannot encode WebP and fall back to PNG, which
+// keeps the transparency.
+func OutputFormat(format string) string {
+	if format == FormatWebP && !webpSupported {
+		return FormatPNG
+	}
+	return format
+}
+
+// Resizer bounds the number of images decoded and resized at once. A
+// decoded image takes four bytes per pixel whatever its file size, so
+// unbounded resizes can exhaust memory under load.
+type Resizer struct {
+	sem chan struct{}
+}
+
+// NewResizer returns a resizer running at most concurrency resizes at once,
+// or one per CPU when concurrency is not positive.
+func NewResizer(concurrency int) *Resizer {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	return &Resizer{
+		sem: make(chan struct{}, concurrency),
+	}
+}
+
+// Resize waits for a free slot and resizes the image like Resize.
+func (r *Resizer) Resize(data []byte, v Variant) ([]byte, error) {
+	r.sem <- struct{}{}
+	defer func() { <-r.sem }()
+
+	return Resize(data, v)
+}
+
+// Resize decodes the image, scales it according to the variant and encodes
+// it in the requested format.
+func Resize(data []byte, v Variant) ([]byte, error) {
+	if _, ok := formatContentTypes[v.Format]; !ok {
+		return nil, ErrInvalidVariant
+	}
+	v.Format = OutputFormat(v.Format)
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrMalformedImage
+	}
+
+	dst, err := scale(src, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch v.Format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	case FormatPNG:
+		err = png.Encode(&buf, dst)
+	case FormatWebP:
+		err = encodeWebP(&buf, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func scale(src image.Image, v Variant) (image.Image, error) {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 {
+		return nil, ErrMalformedImage
+	}
+
+	w, h := v.Width, v.Height
+	switch {
+	case w == 0 && h == 0:
+		w, h = srcW, srcH
+	case w == 0:
+		w = max(1, srcW*h/srcH)
+	case h == 0:
+		h = max(1, srcH*w/srcW)
+	}
+
+	srcRect := bounds
+	dstRect := image.Rect(0, 0, w, h)
+
+	switch v.Fit {
+	case FitFill:
+	case FitContain:
+		// shrink the target to the image aspect ratio
+		if srcW*h > srcH*w {
+			h = max(1, srcH*w/srcW)
+		} else {
+			w = max(1, srcW*h/srcH)
+		}
+		dstRect = image.Rect(0, 0, w, h)
+	case FitCover:
+		// crop the source to the target aspect ratio around its center
+		if srcW*h > srcH*w {
+			cropW := srcH * w / h
+			x0 := bounds.Min.X + (srcW-cropW)/2
+			srcRect = image.Rect(x0, bounds.Min.Y, x0+cropW, bounds.Max.Y)
+		} else {
+			cropH := srcW * h / w
+			y0 := bounds.Min.Y + (srcH-cropH)/2
+			srcRect = image.Rect(bounds.Min.X, y0, bounds.Max.X, y0+cropH)
+		}
+	default:
+		return nil, ErrInvalidVariant
+	}
+
+	dst := image.NewRGBA(dstRect)
+	draw.CatmullRom.Scale(dst, dstRect, src, srcRect, draw.Src, nil)
+
+	return dst, nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
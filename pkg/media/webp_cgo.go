@@ -0,0 +1,18 @@
+//go:build cgo
+
+package media
+
+import (
+	"image"
+	"io"
+
+	"github.com/chai2010/webp"
+)
+
+// webpSupported reports whether WebP variants can be encoded, the encoder
+// wraps libwebp and needs cgo.
+const webpSupported = true
+
+func encodeWebP(w io.Writer, img image.Image) error {
+	return webp.Encode(w, img, &webp.Options{Quality: 80})
+}
@@ -0,0 +1,16 @@
+//go:build !cgo
+
+package media
+
+import (
+	"image"
+	"io"
+)
+
+// webpSupported reports whether WebP variants can be encoded, the encoder
+// wraps libwebp and needs cgo.
+const webpSupported = false
+
+func encodeWebP(w io.Writer, img image.Image) error {
+	return ErrInvalidVariant
+}
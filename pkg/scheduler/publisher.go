@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	pbp "github.com/MuhammadyusufAdhamov/medium_api_gateway/genproto/post_service"
+)
+
+const (
+	statusScheduled = "scheduled"
+	statusPublished = "published"
+
+	pageSize = 100
+)
+
+// Publisher flips scheduled posts to published once their publish_at time
+// comes. It keeps no state of its own: every sync asks the post service for
+// scheduled posts due before the next sync and arms a timer for each, so
+// posts that became due while the gateway was down are published on start.
+type Publisher struct {
+	posts    pbp.PostServiceClient
+	interval time.Duration
+
+	mu     sync.Mutex
+	ctx    context.Context
+	timers map[int64]*time.Timer
+	done   chan struct{}
+}
+
+func New(posts pbp.PostServiceClient, interval time.Duration) *Publisher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &Publisher{
+		posts:    posts,
+		interval: interval,
+		timers:   make(map[int64]*time.Timer),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run syncs with the post service every interval until ctx is cancelled.
+func (p *Publisher) Run(ctx context.Context) {
+	defer close(p.done)
+
+	p.mu.Lock()
+	p.ctx = ctx
+	p.mu.Unlock()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.stopTimers()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Done is closed once Run has returned.
+func (p *Publisher) Done() <-chan struct{} {
+	return p.done
+}
+
+// Schedule arms a timer right away when the post is due before the next
+// sync, so short delays are not rounded up to the sync interval.
+func (p *Publisher) Schedule(postID int64, publishAt time.Time) {
+	if time.Until(publishAt) > p.interval {
+		return
+	}
+
+	p.arm(postID, publishAt)
+}
+
+func (p *Publisher) sync(ctx context.Context) {
+	before := time.Now().Add(p.interval).UTC().Format(time.RFC3339)
+
+	for page := int32(1); ; page++ {
+		resp, err := p.posts.GetAll(ctx, &pbp.GetAllPostsRequest{
+			Limit:         pageSize,
+			Page:          page,
+			Status:        statusScheduled,
+			PublishBefore: before,
+			SortBy:        "date",
+			Order:         "asc",
+		})
+		if err != nil {
+			log.Printf("failed to get scheduled posts: %v", err)
+			return
+		}
+
+		for _, post := range resp.Posts {
+			publishAt, err := time.Parse(time.RFC3339, post.PublishAt)
+			if err != nil {
+				log.Printf("post %d has invalid publish_at %q: %v", post.Id, post.PublishAt, err)
+				continue
+			}
+			p.arm(post.Id, publishAt)
+		}
+
+		if len(resp.Posts) < pageSize || int32(page*pageSize) >= resp.Count {
+			return
+		}
+	}
+}
+
+func (p *Publisher) arm(postID int64, publishAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx == nil || p.ctx.Err() != nil {
+		return
+	}
+
+	if timer, ok := p.timers[postID]; ok {
+		timer.Stop()
+	}
+
+	ctx := p.ctx
+	p.timers[postID] = time.AfterFunc(time.Until(publishAt), func() {
+		p.publish(ctx, postID)
+	})
+}
+
+func (p *Publisher) publish(ctx context.Context, postID int64) {
+	p.mu.Lock()
+	delete(p.timers, postID)
+	p.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	// The author may have rescheduled or unpublished the post since the
+	// timer was armed.
+	post, err := p.posts.Get(ctx, &pbp.GetPostRequest{Id: postID})
+	if err != nil {
+		log.Printf("failed to get scheduled post %d: %v", postID, err)
+		return
+	}
+
+	if post.Status != statusScheduled {
+		return
+	}
+
+	publishAt, err := time.Parse(time.RFC3339, post.PublishAt)
+	if err != nil {
+		return
+	}
+	if publishAt.After(time.Now()) {
+		p.Schedule(postID, publishAt)
+		return
+	}
+
+	_, err = p.posts.UpdateStatus(ctx, &pbp.UpdatePostStatusRequest{
+		Id:        postID,
+		Status:    statusPublished,
+		PublishAt: post.PublishAt,
+	})
+	if err != nil {
+		log.Printf("failed to publish scheduled post %d: %v", postID, err)
+	}
+}
+
+func (p *Publisher) stopTimers() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, timer := range p.timers {
+		timer.Stop()
+		delete(p.timers, id)
+	}
+}
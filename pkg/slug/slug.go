@@ -0,0 +1,41 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Make turns s into a lowercase slug. Letters and digits of any script are
+// kept, accents on Latin letters are dropped (so "café" and "cafe" match),
+// and every other run of characters becomes a single dash.
+func Make(s string) string {
+	var (
+		b     strings.Builder
+		dash  bool
+		latin bool
+	)
+
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.IsMark(r):
+			// marks on other scripts are part of the letter, e.g. "й" or
+			// the vowel signs of Devanagari
+			if !latin && b.Len() > 0 {
+				b.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			latin = r < unicode.MaxASCII || unicode.Is(unicode.Latin, r)
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			dash = true
+		}
+	}
+
+	return norm.NFC.String(b.String())
+}
@@ -0,0 +1,186 @@
+package view_counter
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// FlushFunc persists accumulated view increments keyed by post id.
+type FlushFunc func(ctx context.Context, views map[int64]int32) error
+
+type Options struct {
+	// Window is how long a viewer is remembered for a post; repeated
+	// views inside the window are not counted.
+	Window time.Duration
+	// MaxSeen caps the number of remembered viewers. Once it is reached,
+	// the viewer remembered the longest is forgotten to make room.
+	MaxSeen int
+	// FlushInterval is how often buffered increments are sent.
+	FlushInterval time.Duration
+	// BatchSize is the max number of posts sent in one flush call.
+	// Reaching it also triggers an early flush.
+	BatchSize int
+	Flush     FlushFunc
+}
+
+type seenEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// Counter deduplicates post views per viewer and buffers the increments
+// in memory so they can be written in batches.
+type Counter struct {
+	opts Options
+	mu   sync.Mutex
+	// order holds the seen entries by expiry, the window is the same for
+	// all of them so it is the order they were added in
+	order   *list.List
+	seen    map[string]*list.Element
+	pending map[int64]int32
+	full    chan struct{}
+	done    chan struct{}
+}
+
+func New(opts Options) *Counter {
+	if opts.Window <= 0 {
+		opts.Window = 30 * time.Minute
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 10 * time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.MaxSeen <= 0 {
+		opts.MaxSeen = 100000
+	}
+
+	return &Counter{
+		opts:    opts,
+		order:   list.New(),
+		seen:    make(map[string]*list.Element),
+		pending: make(map[int64]int32),
+		full:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Hit registers a view of the post by the viewer and reports whether it
+// was counted.
+func (c *Counter) Hit(postID int64, viewer string) bool {
+	key := fmt.Sprintf("%d:%s", postID, viewer)
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.seen[key]; ok {
+		if now.Before(el.Value.(*seenEntry).expiresAt) {
+			return false
+		}
+		c.order.Remove(el)
+		delete(c.seen, key)
+	}
+
+	for c.order.Len() >= c.opts.MaxSeen {
+		c.forget(c.order.Front())
+	}
+	c.seen[key] = c.order.PushBack(&seenEntry{key: key, expiresAt: now.Add(c.opts.Window)})
+	c.pending[postID]++
+
+	if len(c.pending) >= c.opts.BatchSize {
+		select {
+		case c.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return true
+}
+
+// Run flushes buffered views until ctx is cancelled, then flushes one
+// last time before returning.
+func (c *Counter) Run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(context.Background())
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+			c.prune()
+		case <-c.full:
+			c.flush(ctx)
+		}
+	}
+}
+
+// Done is closed once Run has returned.
+func (c *Counter) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Counter) flush(ctx context.Context) {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[int64]int32)
+	c.mu.Unlock()
+
+	batch := make(map[int64]int32, c.opts.BatchSize)
+	for postID, count := range pending {
+		batch[postID] = count
+		if len(batch) == c.opts.BatchSize {
+			c.send(ctx, batch)
+			batch = make(map[int64]int32, c.opts.BatchSize)
+		}
+	}
+	if len(batch) > 0 {
+		c.send(ctx, batch)
+	}
+}
+
+func (c *Counter) send(ctx context.Context, batch map[int64]int32) {
+	err := c.opts.Flush(ctx, batch)
+	if err == nil {
+		return
+	}
+
+	log.Printf("failed to flush views of %d posts: %v", len(batch), err)
+
+	// Keep the increments so the next flush retries them.
+	c.mu.Lock()
+	for postID, count := range batch {
+		c.pending[postID] += count
+	}
+	c.mu.Unlock()
+}
+
+func (c *Counter) prune() {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		el := c.order.Front()
+		if now.Before(el.Value.(*seenEntry).expiresAt) {
+			break
+		}
+		c.forget(el)
+	}
+}
+
+func (c *Counter) forget(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.seen, el.Value.(*seenEntry).key)
+}